@@ -0,0 +1,324 @@
+package coll
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+	"sync"
+)
+
+// MaxBitSetElement is the largest element that a [BitSet] accepts.
+//
+// The memory of a BitSet grows with its largest element rather than the number of elements,
+// so a set holding MaxBitSetElement takes 128 KiB however few elements it has.
+const MaxBitSetElement = 1<<20 - 1
+
+// ErrBitSetElementOutOfRange is returned when an element larger than [MaxBitSetElement] is added to a [BitSet].
+var ErrBitSetElementOutOfRange = errors.New("BitSet element out of range")
+
+const (
+	bitSetWordBits = 64
+	// maxBitSetWords is the number of words of a set holding MaxBitSetElement.
+	maxBitSetWords = MaxBitSetElement/bitSetWordBits + 1
+)
+
+// NewBitSet returns a new [BitSet] containing the provided elements.
+// It panics if an element is larger than [MaxBitSetElement].
+func NewBitSet(els ...uint) *BitSet {
+	s := &BitSet{words: nil, mux: sync.RWMutex{}}
+	for _, el := range els {
+		s.add(el)
+	}
+	return s
+}
+
+// BitSet represents a set of small non-negative integers as a bit array.
+// It is safe for concurrent use, and the zero value is an empty set.
+//
+// Each element takes a single bit, so the set is far more compact than a [Set] for dense elements such as IDs in a small range.
+// The memory grows with the largest element rather than the number of elements, which makes it unsuitable for sparse large values;
+// elements are limited to [MaxBitSetElement].
+//
+// The package-level set operations such as [Union] combine two BitSets word by word.
+//
+// Iterators returned by the set hold the read lock for the duration of the iteration,
+// so the set must not be modified from within the loop body.
+type BitSet struct {
+	// words holds the bit of the element i at bit i%64 of words[i/64]. It has no trailing zero words.
+	words []uint64
+	mux   sync.RWMutex
+}
+
+func bitSetPosition(el uint) (int, uint64) {
+	return int(el / bitSetWordBits), 1 << (el % bitSetWordBits)
+}
+
+func (s *BitSet) add(el uint) {
+	if el > MaxBitSetElement {
+		panic(fmt.Sprintf("coll: BitSet element %d is larger than MaxBitSetElement", el))
+	}
+	i, bit := bitSetPosition(el)
+	if i >= len(s.words) {
+		s.words = append(s.words, make([]uint64, i+1-len(s.words))...)
+	}
+	s.words[i] |= bit
+}
+
+// trimBitSetWords removes the trailing zero words.
+func trimBitSetWords(words []uint64) []uint64 {
+	n := len(words)
+	for n > 0 && words[n-1] == 0 {
+		n--
+	}
+	return words[:n]
+}
+
+// Len returns the number of elements in the set, in the same way as [BitSet.Count].
+func (s *BitSet) Len() int {
+	return s.Count()
+}
+
+// Count returns the number of elements in the set.
+// It counts the set bits of every word and is safe for concurrent use.
+func (s *BitSet) Count() int {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	count := 0
+	for _, word := range s.words {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// Contains reports whether the element is present in the set.
+// It is safe for concurrent use.
+func (s *BitSet) Contains(el uint) bool {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	i, bit := bitSetPosition(el)
+	return i < len(s.words) && s.words[i]&bit != 0
+}
+
+// Append adds the element to the set if it does not already exist.
+// It panics if el is larger than [MaxBitSetElement]; use [BitSet.TryAppend] for elements that may be out of range.
+// It is safe for concurrent use.
+func (s *BitSet) Append(el uint) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.add(el)
+}
+
+// TryAppend adds the element to the set if it does not already exist.
+// Unlike [BitSet.Append], it returns [ErrBitSetElementOutOfRange] if el is larger than [MaxBitSetElement].
+// It is safe for concurrent use.
+func (s *BitSet) TryAppend(el uint) error {
+	if el > MaxBitSetElement {
+		return fmt.Errorf("%w: %d is larger than %d", ErrBitSetElementOutOfRange, el, uint(MaxBitSetElement))
+	}
+	s.Append(el)
+	return nil
+}
+
+// Remove removes the element from the set if it exists.
+// It is safe for concurrent use.
+func (s *BitSet) Remove(removedEl uint) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	i, bit := bitSetPosition(removedEl)
+	if i >= len(s.words) {
+		return
+	}
+	s.words[i] &^= bit
+	s.words = trimBitSetWords(s.words)
+}
+
+// Clear removes all elements from the set.
+// It is safe for concurrent use.
+func (s *BitSet) Clear() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.words = nil
+}
+
+// NextSet returns the smallest element of the set greater than or equal to i.
+// The second return value is false if there is no such element. It is safe for concurrent use.
+func (s *BitSet) NextSet(i uint) (uint, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return s.nextSet(i)
+}
+
+func (s *BitSet) nextSet(i uint) (uint, bool) {
+	w, _ := bitSetPosition(i)
+	if w >= len(s.words) {
+		return 0, false
+	}
+	// mask out the bits below i in the first word
+	word := s.words[w] &^ (1<<(i%bitSetWordBits) - 1)
+	for {
+		if word != 0 {
+			return uint(w)*bitSetWordBits + uint(bits.TrailingZeros64(word)), true
+		}
+		w++
+		if w == len(s.words) {
+			return 0, false
+		}
+		word = s.words[w]
+	}
+}
+
+// Values returns an iterator over the elements of the set in ascending order.
+// It is safe for concurrent use.
+func (s *BitSet) Values() iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		s.mux.RLock()
+		defer s.mux.RUnlock()
+		for w, word := range s.words {
+			for word != 0 {
+				if !yield(uint(w)*bitSetWordBits + uint(bits.TrailingZeros64(word))) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
+
+// cloneWords returns a copy of the words of s.
+func (s *BitSet) cloneWords() []uint64 {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return slices.Clone(s.words)
+}
+
+// combineBitSets returns a new set whose words are the results of op applied to the words of xs and ys.
+// Each set is locked in turn rather than together, so that concurrent calls with the operands swapped never deadlock.
+func combineBitSets(xs, ys *BitSet, op func(x, y uint64) uint64) *BitSet {
+	words := xs.cloneWords()
+	ys.mux.RLock()
+	defer ys.mux.RUnlock()
+	return &BitSet{words: combineBitSetWords(words, ys.words, op), mux: sync.RWMutex{}}
+}
+
+// combineBitSetWords overwrites xs with the results of op applied to the words of xs and ys, extending it if ys is longer.
+func combineBitSetWords(xs, ys []uint64, op func(x, y uint64) uint64) []uint64 {
+	if len(ys) > len(xs) {
+		xs = append(xs, make([]uint64, len(ys)-len(xs))...)
+	}
+	for i := range xs {
+		var y uint64
+		if i < len(ys) {
+			y = ys[i]
+		}
+		xs[i] = op(xs[i], y)
+	}
+	return trimBitSetWords(xs)
+}
+
+// compareBitSets reports whether pred holds for every pair of the words of xs and ys.
+// The words that one of the sets lacks are regarded as zero.
+func compareBitSets(xs, ys *BitSet, pred func(x, y uint64) bool) bool {
+	words := xs.cloneWords()
+	ys.mux.RLock()
+	defer ys.mux.RUnlock()
+	for i := range max(len(words), len(ys.words)) {
+		var x, y uint64
+		if i < len(words) {
+			x = words[i]
+		}
+		if i < len(ys.words) {
+			y = ys.words[i]
+		}
+		if !pred(x, y) {
+			return false
+		}
+	}
+	return true
+}
+
+func unionWords(x, y uint64) uint64               { return x | y }
+func intersectWords(x, y uint64) uint64           { return x & y }
+func differenceWords(x, y uint64) uint64          { return x &^ y }
+func symmetricDifferenceWords(x, y uint64) uint64 { return x ^ y }
+
+// UnionWith adds all elements of other to s.
+// If other is a BitSet, the words are combined directly. It is safe for concurrent use.
+// It panics without modifying s if other has an element larger than [MaxBitSetElement].
+func (s *BitSet) UnionWith(other SetLike[uint]) {
+	s.combineWith(other, unionWords, false)
+}
+
+// IntersectWith removes the elements of s that are not present in other.
+// If other is a BitSet, the words are combined directly. It is safe for concurrent use.
+// The elements of other larger than [MaxBitSetElement] are ignored, since s cannot have them.
+func (s *BitSet) IntersectWith(other SetLike[uint]) {
+	s.combineWith(other, intersectWords, true)
+}
+
+// DifferenceWith removes the elements of s that are present in other.
+// If other is a BitSet, the words are combined directly. It is safe for concurrent use.
+// The elements of other larger than [MaxBitSetElement] are ignored, since s cannot have them.
+func (s *BitSet) DifferenceWith(other SetLike[uint]) {
+	s.combineWith(other, differenceWords, true)
+}
+
+// SymmetricDifferenceWith updates s to contain the elements that are in s or other but not in both.
+// If other is a BitSet, the words are combined directly. It is safe for concurrent use.
+// It panics without modifying s if other has an element larger than [MaxBitSetElement].
+func (s *BitSet) SymmetricDifferenceWith(other SetLike[uint]) {
+	s.combineWith(other, symmetricDifferenceWords, false)
+}
+
+// combineWith combines the words of s with those of other by op.
+// If skipOutOfRange is true, the elements of other larger than MaxBitSetElement are dropped, which must not change the result of op.
+func (s *BitSet) combineWith(other SetLike[uint], op func(x, y uint64) uint64, skipOutOfRange bool) {
+	o, ok := other.(*BitSet)
+	// read other before taking the write lock of s so that the two locks are never held together
+	var words []uint64
+	switch {
+	case !ok:
+		b := NewBitSet()
+		for el := range other.Values() {
+			if skipOutOfRange && el > MaxBitSetElement {
+				continue
+			}
+			b.add(el)
+		}
+		words = b.words
+	case o != s:
+		words = o.cloneWords()
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if o == s {
+		words = s.words
+	}
+	s.words = combineBitSetWords(s.words, words, op)
+}
+
+// IsSubset reports whether every element of s is also in other.
+func (s *BitSet) IsSubset(other SetLike[uint]) bool {
+	return IsSubset[uint](s, other)
+}
+
+// IsProperSubset reports whether s is a subset of other and other has at least one element that s does not have.
+func (s *BitSet) IsProperSubset(other SetLike[uint]) bool {
+	return IsProperSubset[uint](s, other)
+}
+
+// IsSuperset reports whether every element of other is also in s.
+func (s *BitSet) IsSuperset(other SetLike[uint]) bool {
+	return IsSuperset[uint](s, other)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+func (s *BitSet) IsDisjoint(other SetLike[uint]) bool {
+	return IsDisjoint[uint](s, other)
+}
+
+// Equal reports whether s and other contain the same elements.
+func (s *BitSet) Equal(other SetLike[uint]) bool {
+	return Equal[uint](s, other)
+}
@@ -0,0 +1,273 @@
+package coll_test
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func TestBitSet(t *testing.T) {
+	s := coll.NewBitSet(130, 1, 64, 1, 63)
+	if got, want := slices.Collect(s.Values()), []uint{1, 63, 64, 130}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values():\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if s.Count() != 4 || s.Len() != 4 {
+		t.Errorf("Count() = %d, Len() = %d, want 4", s.Count(), s.Len())
+	}
+	if !s.Contains(64) || s.Contains(65) || s.Contains(1<<20) {
+		t.Error("Contains() reports unexpected results")
+	}
+	s.Remove(130)
+	s.Remove(1 << 20)
+	s.Append(2)
+	if got, want := slices.Collect(s.Values()), []uint{1, 2, 63, 64}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values() after updates:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	s.Clear()
+	if s.Len() != 0 || s.Contains(1) {
+		t.Error("Clear() leaves elements")
+	}
+}
+
+func TestBitSet_zero(t *testing.T) {
+	var s coll.BitSet
+	if s.Len() != 0 || s.Contains(0) {
+		t.Error("the zero value is not empty")
+	}
+	if _, ok := s.NextSet(0); ok {
+		t.Error("NextSet() of an empty set reports an element")
+	}
+	s.Append(0)
+	if !s.Contains(0) {
+		t.Error("Append() to the zero value does not add the element")
+	}
+}
+
+func TestBitSet_outOfRange(t *testing.T) {
+	s := coll.NewBitSet()
+	for _, el := range []uint{coll.MaxBitSetElement + 1, math.MaxUint} {
+		if err := s.TryAppend(el); !errors.Is(err, coll.ErrBitSetElementOutOfRange) {
+			t.Errorf("TryAppend(%d) returns %v, want ErrBitSetElementOutOfRange", el, err)
+		}
+		if s.Contains(el) || s.Len() != 0 {
+			t.Errorf("TryAppend(%d) adds the element", el)
+		}
+	}
+	if err := s.TryAppend(1000); err != nil || !s.Contains(1000) {
+		t.Errorf("TryAppend(1000) returns %v", err)
+	}
+	defer func() {
+		msg, ok := recover().(string)
+		if !ok || !strings.Contains(msg, "larger than MaxBitSetElement") {
+			t.Errorf("Append() of an out-of-range element panics with %#v", msg)
+		}
+	}()
+	s.Append(math.MaxUint)
+}
+
+func TestBitSet_NextSet(t *testing.T) {
+	s := coll.NewBitSet(3, 64, 200)
+	testCases := []struct {
+		arg    uint
+		want   uint
+		wantOK bool
+	}{
+		{arg: 0, want: 3, wantOK: true},
+		{arg: 3, want: 3, wantOK: true},
+		{arg: 4, want: 64, wantOK: true},
+		{arg: 65, want: 200, wantOK: true},
+		{arg: 201, want: 0, wantOK: false},
+		{arg: 1 << 20, want: 0, wantOK: false},
+	}
+	for _, tc := range testCases {
+		if got, ok := s.NextSet(tc.arg); got != tc.want || ok != tc.wantOK {
+			t.Errorf("NextSet(%d) = (%d, %v), want (%d, %v)", tc.arg, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestBitSet_setOps(t *testing.T) {
+	rng := rand.New(rand.NewPCG(9, 10))
+	for range 50 {
+		xs, ys := coll.NewBitSet(), coll.NewBitSet()
+		mx, my := coll.NewSet[uint](), coll.NewSet[uint]()
+		for range rng.IntN(100) {
+			el := rng.UintN(300)
+			xs.Append(el)
+			mx.Append(el)
+		}
+		for range rng.IntN(100) {
+			el := rng.UintN(300)
+			ys.Append(el)
+			my.Append(el)
+		}
+		ops := []struct {
+			op   func(xs, ys coll.SetLike[uint]) coll.SetLike[uint]
+			name string
+		}{
+			{name: "Union", op: coll.Union[uint]},
+			{name: "Intersect", op: coll.Intersect[uint]},
+			{name: "Difference", op: coll.Difference[uint]},
+			{name: "SymmetricDifference", op: coll.SymmetricDifference[uint]},
+		}
+		for _, op := range ops {
+			got := op.op(xs, ys)
+			if _, ok := got.(*coll.BitSet); !ok {
+				t.Fatalf("%s() returns %T, want *coll.BitSet", op.name, got)
+			}
+			if want := slices.Sorted(op.op(mx, my).Values()); !slices.Equal(want, slices.Collect(got.Values())) || got.Len() != len(want) {
+				t.Fatalf("%s() mismatch:\n\twant: %#v\n\t got: %#v", op.name, want, slices.Collect(got.Values()))
+			}
+		}
+		predicates := []struct {
+			pred func(xs, ys coll.SetLike[uint]) bool
+			name string
+		}{
+			{name: "IsSubset", pred: coll.IsSubset[uint]},
+			{name: "IsProperSubset", pred: coll.IsProperSubset[uint]},
+			{name: "IsDisjoint", pred: coll.IsDisjoint[uint]},
+			{name: "Equal", pred: coll.Equal[uint]},
+		}
+		sub := coll.Intersect[uint](xs, ys)
+		for _, pred := range predicates {
+			for _, pair := range [][2]coll.SetLike[uint]{{xs, ys}, {sub, xs}, {xs, xs}} {
+				want := pred.pred(coll.NewSet(slices.Collect(pair[0].Values())...), coll.NewSet(slices.Collect(pair[1].Values())...))
+				if got := pred.pred(pair[0], pair[1]); got != want {
+					t.Fatalf("%s() = %v, want %v", pred.name, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestBitSet_setOps_mixed(t *testing.T) {
+	xs := coll.NewBitSet(1, 2, 3)
+	got := coll.Union[uint](xs, coll.NewSet[uint](3, 4))
+	if _, ok := got.(*coll.BitSet); !ok {
+		t.Errorf("Union() returns %T, want *coll.BitSet", got)
+	}
+	if want := []uint{1, 2, 3, 4}; !slices.Equal(want, slices.Collect(got.Values())) {
+		t.Errorf("Union() mismatch:\n\twant: %#v\n\t got: %#v", want, slices.Collect(got.Values()))
+	}
+	if !coll.Equal[uint](coll.NewSet[uint](3, 2, 1), xs) || !coll.IsSubset[uint](xs, coll.NewOrderedSet[uint](0, 1, 2, 3)) {
+		t.Error("predicates report unexpected results with a non-BitSet operand")
+	}
+}
+
+func TestBitSet_setOps_outOfRange(t *testing.T) {
+	const large = coll.MaxBitSetElement + 1
+	xs := coll.NewBitSet(1, 2)
+	ys := coll.NewSet[uint](2, large)
+	testCases := []struct {
+		got    coll.SetLike[uint]
+		name   string
+		want   []uint
+		bitSet bool
+	}{
+		{name: "Union", got: coll.Union[uint](xs, ys), want: []uint{1, 2, large}},
+		{name: "UnionAll", got: coll.UnionAll[uint](xs, coll.NewBitSet(3), ys), want: []uint{1, 2, 3, large}},
+		{name: "SymmetricDifference", got: coll.SymmetricDifference[uint](xs, ys), want: []uint{1, large}},
+		{name: "Intersect", got: coll.Intersect[uint](xs, ys), want: []uint{2}, bitSet: true},
+		{name: "Difference", got: coll.Difference[uint](xs, ys), want: []uint{1}, bitSet: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, ok := tc.got.(*coll.BitSet); ok != tc.bitSet {
+				t.Errorf("%s() returns %T", tc.name, tc.got)
+			}
+			if got := slices.Collect(tc.got.Values()); !slices.Equal(tc.want, got) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, got)
+			}
+		})
+	}
+	t.Run("in place", func(t *testing.T) {
+		s := coll.NewBitSet(1, 2)
+		s.IntersectWith(ys)
+		if got, want := slices.Collect(s.Values()), []uint{2}; !slices.Equal(want, got) {
+			t.Errorf("IntersectWith() mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+		}
+		s.DifferenceWith(ys)
+		if s.Len() != 0 {
+			t.Errorf("DifferenceWith() leaves %v", slices.Collect(s.Values()))
+		}
+		s.Append(1)
+		defer func() {
+			if recover() == nil {
+				t.Error("UnionWith() of an out-of-range element does not panic")
+			}
+			if got, want := slices.Collect(s.Values()), []uint{1}; !slices.Equal(want, got) {
+				t.Errorf("UnionWith() modifies the set before panicking:\n\twant: %#v\n\t got: %#v", want, got)
+			}
+		}()
+		s.UnionWith(ys)
+	})
+}
+
+func TestBitSet_inPlace(t *testing.T) {
+	testCases := []struct {
+		other  coll.SetLike[uint]
+		update func(s *coll.BitSet, other coll.SetLike[uint])
+		name   string
+		want   []uint
+	}{
+		{name: "UnionWith", update: (*coll.BitSet).UnionWith, other: coll.NewBitSet(3, 200), want: []uint{1, 2, 3, 200}},
+		{name: "UnionWith/Set", update: (*coll.BitSet).UnionWith, other: coll.NewSet[uint](3, 200), want: []uint{1, 2, 3, 200}},
+		{name: "IntersectWith", update: (*coll.BitSet).IntersectWith, other: coll.NewBitSet(2, 3, 200), want: []uint{2, 3}},
+		{name: "DifferenceWith", update: (*coll.BitSet).DifferenceWith, other: coll.NewBitSet(2, 200), want: []uint{1, 3}},
+		{name: "SymmetricDifferenceWith", update: (*coll.BitSet).SymmetricDifferenceWith, other: coll.NewBitSet(3, 200), want: []uint{1, 2, 200}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := coll.NewBitSet(1, 2, 3)
+			tc.update(s, tc.other)
+			if got := slices.Collect(s.Values()); !slices.Equal(tc.want, got) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, got)
+			}
+		})
+	}
+	t.Run("self", func(t *testing.T) {
+		s := coll.NewBitSet(1, 2, 3)
+		s.UnionWith(s)
+		s.IntersectWith(s)
+		if s.Len() != 3 {
+			t.Errorf("Len() = %d after updating with itself, want 3", s.Len())
+		}
+		s.DifferenceWith(s)
+		if s.Len() != 0 {
+			t.Errorf("Len() = %d after subtracting itself, want 0", s.Len())
+		}
+	})
+}
+
+func BenchmarkBitSet_Union(b *testing.B) {
+	xs, ys := coll.NewBitSet(), coll.NewBitSet()
+	sx, sy := coll.NewSet[uint](), coll.NewSet[uint]()
+	for i := range uint(4096) {
+		if i%2 == 0 {
+			xs.Append(i)
+			sx.Append(i)
+		}
+		if i%3 == 0 {
+			ys.Append(i)
+			sy.Append(i)
+		}
+	}
+	b.Run("BitSet", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = coll.Union[uint](xs, ys)
+		}
+	})
+	b.Run("Set", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = coll.Union[uint](sx, sy)
+		}
+	})
+}
@@ -0,0 +1,400 @@
+package coll
+
+import "slices"
+
+// btreeDegree is the minimum degree of btree: every node except the root holds between btreeDegree-1 and 2*btreeDegree-1 items.
+const btreeDegree = 16
+
+const btreeMaxItems = 2*btreeDegree - 1
+
+// btree is a B-tree ordered by compare, augmented with the size of every subtree so that it answers rank and select queries in O(log n).
+// The zero value with a non-nil compare is an empty tree.
+//
+// It is not safe for concurrent use; the owner must guard it.
+type btree[K, V any] struct {
+	root    *btreeNode[K, V]
+	compare func(a, b K) int
+}
+
+type btreeItem[K, V any] struct {
+	key   K
+	value V
+}
+
+// btreeNode is a node of btree. A node is a leaf if it has no children; otherwise it has len(items)+1 children.
+type btreeNode[K, V any] struct {
+	items    []btreeItem[K, V]
+	children []*btreeNode[K, V]
+	size     int // the number of items in the subtree
+}
+
+func (n *btreeNode[K, V]) leaf() bool { return len(n.children) == 0 }
+
+// search returns the index of the first item whose key is not less than key, and whether the key of the item equals key.
+func (n *btreeNode[K, V]) search(key K, compare func(a, b K) int) (int, bool) {
+	return slices.BinarySearchFunc(n.items, key, func(item btreeItem[K, V], key K) int { return compare(item.key, key) })
+}
+
+func (t *btree[K, V]) len() int {
+	if t.root == nil {
+		return 0
+	}
+	return t.root.size
+}
+
+// get returns the item whose key equals key.
+func (t *btree[K, V]) get(key K) (*btreeItem[K, V], bool) {
+	for n := t.root; n != nil; {
+		i, found := n.search(key, t.compare)
+		if found {
+			return &n.items[i], true
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return nil, false
+}
+
+// set associates key with value, and reports whether key is newly added.
+func (t *btree[K, V]) set(key K, value V) bool {
+	if item, found := t.get(key); found {
+		item.value = value
+		return false
+	}
+	item := btreeItem[K, V]{key: key, value: value}
+	if t.root == nil {
+		t.root = &btreeNode[K, V]{items: []btreeItem[K, V]{item}, children: nil, size: 1}
+		return true
+	}
+	if len(t.root.items) == btreeMaxItems {
+		t.root = &btreeNode[K, V]{items: nil, children: []*btreeNode[K, V]{t.root}, size: t.root.size}
+		t.root.splitChild(0)
+	}
+	// split every full node on the way down so that the leaf always has room for the new item
+	n := t.root
+	for {
+		n.size++
+		i, _ := n.search(key, t.compare)
+		if n.leaf() {
+			n.items = slices.Insert(n.items, i, item)
+			return true
+		}
+		if len(n.children[i].items) == btreeMaxItems {
+			n.splitChild(i)
+			if t.compare(key, n.items[i].key) > 0 {
+				i++
+			}
+		}
+		n = n.children[i]
+	}
+}
+
+// splitChild splits the full i-th child of n into two, moving its median item up into n.
+func (n *btreeNode[K, V]) splitChild(i int) {
+	left := n.children[i]
+	const mid = btreeDegree - 1
+	right := &btreeNode[K, V]{items: slices.Clone(left.items[mid+1:]), children: nil, size: 0}
+	median := left.items[mid]
+	clear(left.items[mid:])
+	left.items = left.items[:mid]
+	if !left.leaf() {
+		right.children = slices.Clone(left.children[mid+1:])
+		clear(left.children[mid+1:])
+		left.children = left.children[:mid+1]
+	}
+	right.size = right.countSize()
+	left.size -= right.size + 1
+	n.items = slices.Insert(n.items, i, median)
+	n.children = slices.Insert(n.children, i+1, right)
+}
+
+func (n *btreeNode[K, V]) countSize() int {
+	size := len(n.items)
+	for _, child := range n.children {
+		size += child.size
+	}
+	return size
+}
+
+// delete removes the item whose key equals key and returns it.
+func (t *btree[K, V]) delete(key K) (btreeItem[K, V], bool) {
+	if _, found := t.get(key); !found {
+		var zero btreeItem[K, V]
+		return zero, false
+	}
+	removed := t.root.delete(key, t.compare)
+	if len(t.root.items) == 0 {
+		if t.root.leaf() {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+	return removed, true
+}
+
+// delete removes the item whose key equals key from the subtree, which must contain it.
+// Every node it descends into is first given at least btreeDegree items, so that removing an item from it never underflows.
+func (n *btreeNode[K, V]) delete(key K, compare func(a, b K) int) btreeItem[K, V] {
+	n.size--
+	i, found := n.search(key, compare)
+	if n.leaf() {
+		removed := n.items[i]
+		n.items = slices.Delete(n.items, i, i+1)
+		return removed
+	}
+	if !found {
+		i = n.fillChild(i)
+		return n.children[i].delete(key, compare)
+	}
+	removed := n.items[i]
+	switch {
+	case len(n.children[i].items) >= btreeDegree:
+		n.items[i] = n.children[i].deleteMax()
+	case len(n.children[i+1].items) >= btreeDegree:
+		n.items[i] = n.children[i+1].deleteMin()
+	default:
+		n.merge(i)
+		n.children[i].delete(key, compare)
+	}
+	return removed
+}
+
+func (n *btreeNode[K, V]) deleteMin() btreeItem[K, V] {
+	n.size--
+	if n.leaf() {
+		removed := n.items[0]
+		n.items = slices.Delete(n.items, 0, 1)
+		return removed
+	}
+	return n.children[n.fillChild(0)].deleteMin()
+}
+
+func (n *btreeNode[K, V]) deleteMax() btreeItem[K, V] {
+	n.size--
+	if n.leaf() {
+		last := len(n.items) - 1
+		removed := n.items[last]
+		n.items = slices.Delete(n.items, last, last+1)
+		return removed
+	}
+	return n.children[n.fillChild(len(n.children)-1)].deleteMax()
+}
+
+// fillChild makes the i-th child of n hold at least btreeDegree items by borrowing an item from a sibling or merging with one.
+// It returns the index of the child after the change.
+func (n *btreeNode[K, V]) fillChild(i int) int {
+	child := n.children[i]
+	switch {
+	case len(child.items) >= btreeDegree:
+		return i
+	case i > 0 && len(n.children[i-1].items) >= btreeDegree:
+		left := n.children[i-1]
+		last := len(left.items) - 1
+		child.items = slices.Insert(child.items, 0, n.items[i-1])
+		n.items[i-1] = left.items[last]
+		left.items = slices.Delete(left.items, last, last+1)
+		moved := 1
+		if !left.leaf() {
+			grandchild := left.children[last+1]
+			child.children = slices.Insert(child.children, 0, grandchild)
+			left.children = slices.Delete(left.children, last+1, last+2)
+			moved += grandchild.size
+		}
+		left.size -= moved
+		child.size += moved
+		return i
+	case i < len(n.items) && len(n.children[i+1].items) >= btreeDegree:
+		right := n.children[i+1]
+		child.items = append(child.items, n.items[i])
+		n.items[i] = right.items[0]
+		right.items = slices.Delete(right.items, 0, 1)
+		moved := 1
+		if !right.leaf() {
+			grandchild := right.children[0]
+			child.children = append(child.children, grandchild)
+			right.children = slices.Delete(right.children, 0, 1)
+			moved += grandchild.size
+		}
+		right.size -= moved
+		child.size += moved
+		return i
+	}
+	if i == len(n.items) {
+		i--
+	}
+	n.merge(i)
+	return i
+}
+
+// merge merges the i-th item of n and the (i+1)-th child into the i-th child.
+func (n *btreeNode[K, V]) merge(i int) {
+	left, right := n.children[i], n.children[i+1]
+	left.items = append(append(left.items, n.items[i]), right.items...)
+	left.children = append(left.children, right.children...)
+	left.size += 1 + right.size
+	n.items = slices.Delete(n.items, i, i+1)
+	n.children = slices.Delete(n.children, i+1, i+2)
+}
+
+// min returns the item with the smallest key.
+func (t *btree[K, V]) min() (*btreeItem[K, V], bool) {
+	if t.root == nil {
+		return nil, false
+	}
+	n := t.root
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return &n.items[0], true
+}
+
+// max returns the item with the largest key.
+func (t *btree[K, V]) max() (*btreeItem[K, V], bool) {
+	if t.root == nil {
+		return nil, false
+	}
+	n := t.root
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return &n.items[len(n.items)-1], true
+}
+
+// floor returns the item with the largest key less than or equal to key.
+func (t *btree[K, V]) floor(key K) (*btreeItem[K, V], bool) {
+	var best *btreeItem[K, V]
+	for n := t.root; n != nil; {
+		i, found := n.search(key, t.compare)
+		if found {
+			return &n.items[i], true
+		}
+		if i > 0 {
+			best = &n.items[i-1]
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return best, best != nil
+}
+
+// ceiling returns the item with the smallest key greater than or equal to key.
+func (t *btree[K, V]) ceiling(key K) (*btreeItem[K, V], bool) {
+	var best *btreeItem[K, V]
+	for n := t.root; n != nil; {
+		i, found := n.search(key, t.compare)
+		if found {
+			return &n.items[i], true
+		}
+		if i < len(n.items) {
+			best = &n.items[i]
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return best, best != nil
+}
+
+// rank returns the number of items whose keys are less than key.
+func (t *btree[K, V]) rank(key K) int {
+	rank := 0
+	for n := t.root; n != nil; {
+		i, found := n.search(key, t.compare)
+		rank += i
+		if n.leaf() {
+			break
+		}
+		for _, child := range n.children[:i] {
+			rank += child.size
+		}
+		if found {
+			rank += n.children[i].size
+			break
+		}
+		n = n.children[i]
+	}
+	return rank
+}
+
+// at returns the item at index i in ascending order of the keys.
+func (t *btree[K, V]) at(i int) (*btreeItem[K, V], bool) {
+	if i < 0 || i >= t.len() {
+		return nil, false
+	}
+	n := t.root
+	for {
+		j := 0
+		for ; j < len(n.items); j++ {
+			if !n.leaf() {
+				size := n.children[j].size
+				if i < size {
+					break
+				}
+				i -= size
+			}
+			if i == 0 {
+				return &n.items[j], true
+			}
+			i--
+		}
+		n = n.children[j]
+	}
+}
+
+// ascend calls yield for the items whose keys are greater than or equal to *lo in ascending order, until yield returns false.
+// If lo is nil, it starts from the smallest item.
+func (t *btree[K, V]) ascend(lo *K, yield func(*btreeItem[K, V]) bool) {
+	t.root.ascend(lo, t.compare, yield)
+}
+
+func (n *btreeNode[K, V]) ascend(lo *K, compare func(a, b K) int, yield func(*btreeItem[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	start, found := 0, false
+	if lo != nil {
+		start, found = n.search(*lo, compare)
+	}
+	for i := start; i <= len(n.items); i++ {
+		// the items in the child left of an item equal to *lo are all less than it
+		if !n.leaf() && (i != start || !found) {
+			childLo := lo
+			if i != start {
+				childLo = nil
+			}
+			if !n.children[i].ascend(childLo, compare, yield) {
+				return false
+			}
+		}
+		if i < len(n.items) && !yield(&n.items[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// descend calls yield for every item in descending order, until yield returns false.
+func (t *btree[K, V]) descend(yield func(*btreeItem[K, V]) bool) {
+	t.root.descend(yield)
+}
+
+func (n *btreeNode[K, V]) descend(yield func(*btreeItem[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	for i := len(n.items); i >= 0; i-- {
+		if !n.leaf() && !n.children[i].descend(yield) {
+			return false
+		}
+		if i > 0 && !yield(&n.items[i-1]) {
+			return false
+		}
+	}
+	return true
+}
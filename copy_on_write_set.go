@@ -0,0 +1,157 @@
+package coll
+
+import (
+	"iter"
+	"maps"
+	"sync"
+	"sync/atomic"
+)
+
+// NewCopyOnWriteSet returns a new [CopyOnWriteSet] containing the provided elements.
+// Duplicates in the input are ignored.
+func NewCopyOnWriteSet[E comparable](els ...E) *CopyOnWriteSet[E] {
+	s := &CopyOnWriteSet[E]{
+		current: atomic.Pointer[setSnapshot[E]]{},
+		mux:     sync.Mutex{},
+	}
+	s.current.Store(newSetSnapshot(NewUnsyncSet(els...).values))
+	return s
+}
+
+// CopyOnWriteSet represents a set of comparable elements for read-mostly workloads.
+// It is safe for concurrent use.
+//
+// The elements are kept in an immutable snapshot that is published through an [atomic.Pointer].
+// Reads load the current snapshot and never block, while every write copies the elements, applies the change
+// and publishes the copy as a new snapshot; writes are serialized by a mutex.
+// Since each write costs O(n), prefer [Set] unless reads vastly outnumber writes.
+//
+// Iterators iterate over the snapshot that is current when the iteration starts,
+// so the set may be modified from within the loop body without affecting the iteration.
+type CopyOnWriteSet[E comparable] struct {
+	current atomic.Pointer[setSnapshot[E]]
+	mux     sync.Mutex
+}
+
+// setSnapshot is an immutable set. It is never modified once published.
+type setSnapshot[E comparable] struct {
+	values map[E]struct{}
+}
+
+func newSetSnapshot[E comparable](values map[E]struct{}) *setSnapshot[E] {
+	return &setSnapshot[E]{values: values}
+}
+
+func (s *setSnapshot[E]) Len() int { return len(s.values) }
+
+func (s *setSnapshot[E]) Contains(el E) bool {
+	_, found := s.values[el]
+	return found
+}
+
+func (s *setSnapshot[E]) Values() iter.Seq[E] {
+	return maps.Keys(s.values)
+}
+
+func (s *CopyOnWriteSet[E]) load() *setSnapshot[E] {
+	if snapshot := s.current.Load(); snapshot != nil {
+		return snapshot
+	}
+	return newSetSnapshot[E](nil)
+}
+
+// Snapshot returns the current elements of the set as an immutable [SetLike].
+// Later writes to the set do not change the returned snapshot. It is wait-free.
+func (s *CopyOnWriteSet[E]) Snapshot() SetLike[E] {
+	return s.load()
+}
+
+// Len returns the number of elements in the set.
+// It is wait-free.
+func (s *CopyOnWriteSet[E]) Len() int {
+	return s.load().Len()
+}
+
+// Contains reports whether the element is present in the set.
+// It is wait-free.
+func (s *CopyOnWriteSet[E]) Contains(el E) bool {
+	return s.load().Contains(el)
+}
+
+// Values returns an iterator over the elements of the set.
+// It is wait-free.
+func (s *CopyOnWriteSet[E]) Values() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		s.load().Values()(yield)
+	}
+}
+
+// Append adds the element to the set if it does not already exist.
+// It copies the elements unless the element already exists. It is safe for concurrent use.
+func (s *CopyOnWriteSet[E]) Append(el E) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.load().Contains(el) {
+		return
+	}
+	s.unsafeUpdate(func(values *UnsyncSet[E]) { values.Append(el) })
+}
+
+// Remove removes the element from the set if it exists.
+// It copies the elements unless the element does not exist. It is safe for concurrent use.
+func (s *CopyOnWriteSet[E]) Remove(removedEl E) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if !s.load().Contains(removedEl) {
+		return
+	}
+	s.unsafeUpdate(func(values *UnsyncSet[E]) { values.Remove(removedEl) })
+}
+
+// Clear removes all elements from the set.
+// It is safe for concurrent use.
+func (s *CopyOnWriteSet[E]) Clear() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.current.Store(newSetSnapshot[E](nil))
+}
+
+// Update applies update to a copy of the elements and publishes the result as a single new snapshot,
+// so that a batch of changes costs one copy and readers never observe a partially applied batch.
+// update must not retain the given set after it returns. It is safe for concurrent use.
+func (s *CopyOnWriteSet[E]) Update(update func(s *UnsyncSet[E])) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.unsafeUpdate(update)
+}
+
+func (s *CopyOnWriteSet[E]) unsafeUpdate(update func(s *UnsyncSet[E])) {
+	values := &UnsyncSet[E]{values: maps.Clone(s.load().values)}
+	update(values)
+	s.current.Store(newSetSnapshot(values.values))
+}
+
+// IsSubset reports whether every element of s is also in other.
+func (s *CopyOnWriteSet[E]) IsSubset(other SetLike[E]) bool {
+	return IsSubset(s.Snapshot(), other)
+}
+
+// IsProperSubset reports whether s is a subset of other and other has at least one element that s does not have.
+func (s *CopyOnWriteSet[E]) IsProperSubset(other SetLike[E]) bool {
+	return IsProperSubset(s.Snapshot(), other)
+}
+
+// IsSuperset reports whether every element of other is also in s.
+func (s *CopyOnWriteSet[E]) IsSuperset(other SetLike[E]) bool {
+	return IsSuperset(s.Snapshot(), other)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+func (s *CopyOnWriteSet[E]) IsDisjoint(other SetLike[E]) bool {
+	return IsDisjoint(s.Snapshot(), other)
+}
+
+// Equal reports whether s and other contain the same elements, regardless of their order.
+func (s *CopyOnWriteSet[E]) Equal(other SetLike[E]) bool {
+	return Equal(s.Snapshot(), other)
+}
@@ -0,0 +1,169 @@
+package coll_test
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func TestCopyOnWriteSet(t *testing.T) {
+	strSet := coll.NewCopyOnWriteSet("c", "a", "b", "a")
+	if gotLen := strSet.Len(); gotLen != 3 {
+		t.Errorf("Len() returns unexpected value: %d", gotLen)
+	}
+	if got, want := slices.Sorted(strSet.Values()), []string{"a", "b", "c"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values() returns the unexpected value:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	strSet.Append("z")
+	if !strSet.Contains("z") {
+		t.Error("the set says it DOES NOT contain 'z'")
+	}
+	strSet.Remove("a")
+	if strSet.Contains("a") {
+		t.Error("the set says it DOES contain 'a'")
+	}
+	strSet.Update(func(s *coll.UnsyncSet[string]) {
+		s.Append("x")
+		s.Remove("z")
+	})
+	if got, want := slices.Sorted(strSet.Values()), []string{"b", "c", "x"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values() returns the unexpected value:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	strSet.Clear()
+	if gotLen := strSet.Len(); gotLen != 0 {
+		t.Errorf("Len() returns unexpected value: %d", gotLen)
+	}
+}
+
+func TestCopyOnWriteSet_empty(t *testing.T) {
+	nums := new(coll.CopyOnWriteSet[int])
+	if got := nums.Contains(42); got {
+		t.Errorf("Contains(42) reports true unexpectedly")
+	}
+	if got := nums.Snapshot().Len(); got != 0 {
+		t.Errorf("Snapshot().Len() returns unexpected value: %d", got)
+	}
+	nums.Append(42)
+	if got, want := slices.Collect(nums.Values()), []int{42}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values() returns the unexpected value:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+func TestCopyOnWriteSet_Snapshot(t *testing.T) {
+	s := coll.NewCopyOnWriteSet("a", "b")
+	snapshot := s.Snapshot()
+	s.Append("c")
+	s.Remove("a")
+	s.Update(func(s *coll.UnsyncSet[string]) { s.Append("d") })
+	s.Clear()
+	if got, want := slices.Sorted(snapshot.Values()), []string{"a", "b"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("the snapshot is changed by the later writes:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if !snapshot.Contains("a") || snapshot.Contains("c") {
+		t.Error("Contains() of the snapshot reflects the later writes")
+	}
+}
+
+func TestCopyOnWriteSet_modifyWithinLoop(t *testing.T) {
+	s := coll.NewCopyOnWriteSet(1, 2, 3)
+	n := 0
+	for el := range s.Values() {
+		s.Remove(el)
+		s.Append(el * 10)
+		n++
+	}
+	if n != 3 {
+		t.Errorf("the loop body runs %d times", n)
+	}
+	if got, want := slices.Sorted(s.Values()), []int{10, 20, 30}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values() returns the unexpected value:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+func TestCopyOnWriteSet_predicates(t *testing.T) {
+	xs := coll.NewCopyOnWriteSet("a", "b")
+	if !xs.IsSubset(coll.NewOrderedSet("b", "a", "c")) {
+		t.Error("IsSubset() reports false unexpectedly")
+	}
+	if !xs.IsProperSubset(coll.NewSet("a", "b", "c")) {
+		t.Error("IsProperSubset() reports false unexpectedly")
+	}
+	if !xs.IsSuperset(coll.NewSet("a")) {
+		t.Error("IsSuperset() reports false unexpectedly")
+	}
+	if !xs.IsDisjoint(coll.NewSet("c")) {
+		t.Error("IsDisjoint() reports false unexpectedly")
+	}
+	if !xs.Equal(xs) {
+		t.Error("Equal() reports false unexpectedly")
+	}
+}
+
+func TestCopyOnWriteSet_concurrentAccess(t *testing.T) {
+	nums := coll.NewCopyOnWriteSet[int]()
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range 100 {
+				nums.Append(i*100 + n)
+				if n%3 == 0 {
+					nums.Remove(i*100 + n)
+				}
+			}
+		}()
+	}
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				snapshot := nums.Snapshot()
+				if got := len(slices.Collect(snapshot.Values())); got != snapshot.Len() {
+					t.Errorf("the snapshot yields %d elements but Len() returns %d", got, snapshot.Len())
+				}
+				_ = nums.Contains(42)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := nums.Len(); got != 4*66 {
+		t.Errorf("Len() returns unexpected value: %d", got)
+	}
+}
+
+func BenchmarkCopyOnWriteSet_Contains_parallel(b *testing.B) {
+	const size = 1_000
+	b.Run("Set", func(b *testing.B) {
+		s := coll.NewSet[int]()
+		for i := range size {
+			s.Append(i)
+		}
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				_ = s.Contains(i % size)
+				i++
+			}
+		})
+	})
+	b.Run("CopyOnWriteSet", func(b *testing.B) {
+		s := coll.NewCopyOnWriteSet[int]()
+		s.Update(func(s *coll.UnsyncSet[int]) {
+			for i := range size {
+				s.Append(i)
+			}
+		})
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				_ = s.Contains(i % size)
+				i++
+			}
+		})
+	})
+}
@@ -1,6 +1,7 @@
 package coll_test
 
 import (
+	"flag"
 	"fmt"
 
 	"github.com/aereal/coll"
@@ -23,3 +24,13 @@ func ExampleOrderedSet() {
 	// appended nums contains 42?: true
 	// values: 3 1 2 42
 }
+
+func ExampleStringSetFlag() {
+	tags := coll.NewOrderedSet[string]()
+	fs := flag.NewFlagSet("example", flag.ExitOnError)
+	fs.Var(coll.StringSetFlag(tags), "tag", "tags to attach (repeatable, comma-separated)")
+	_ = fs.Parse([]string{"-tag", "web", "-tag", "db,web", "-tag", "cache"})
+	fmt.Println(fs.Lookup("tag").Value)
+	// Output:
+	// web,db,cache
+}
@@ -0,0 +1,144 @@
+package coll
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoSetFlagParser is returned by [SetFlag.Set] of the zero value if the element type has no default parser.
+var ErrNoSetFlagParser = errors.New("no parser for the SetFlag element type")
+
+// SetFlag is a [flag.Value] that collects command-line flag values into an [OrderedSet].
+//
+// The flag may be repeated (-tag a -tag b) and each occurrence may carry a comma-separated list (-tags a,b,c).
+// The values accumulate in the order they are first seen and duplicates are ignored.
+// Individual values cannot contain commas.
+//
+// SetFlag also implements [encoding.TextUnmarshaler] with the same accumulating semantics,
+// and [encoding.TextMarshaler] that renders the canonical comma-separated form.
+//
+// The zero value is ready to use: it collects the values into a new set that [SetFlag.Get] returns,
+// parses strings as they are and other element types by their [encoding.TextUnmarshaler] implementation,
+// and renders the elements with [fmt.Sprint].
+//
+// [OrderedSet] does not implement [flag.Value] itself, since its String method renders the set for printing
+// as described in [OrderedSet.String], and it has no way to parse elements other than strings.
+type SetFlag[E comparable] struct {
+	set    *OrderedSet[E]
+	parse  func(string) (E, error)
+	format func(E) string
+}
+
+// NewSetFlag returns a new [SetFlag] that adds the values parsed by parse to set and renders them with format.
+func NewSetFlag[E comparable](set *OrderedSet[E], parse func(string) (E, error), format func(E) string) *SetFlag[E] {
+	return &SetFlag[E]{set: set, parse: parse, format: format}
+}
+
+// StringSetFlag returns a new [SetFlag] that adds string values to set.
+func StringSetFlag(set *OrderedSet[string]) *SetFlag[string] {
+	return NewSetFlag(set, func(s string) (string, error) { return s, nil }, func(s string) string { return s })
+}
+
+// IntSetFlag returns a new [SetFlag] that adds decimal integer values to set.
+func IntSetFlag(set *OrderedSet[int]) *SetFlag[int] {
+	return NewSetFlag(set, strconv.Atoi, strconv.Itoa)
+}
+
+// DurationSetFlag returns a new [SetFlag] that adds values accepted by [time.ParseDuration] to set.
+func DurationSetFlag(set *OrderedSet[time.Duration]) *SetFlag[time.Duration] {
+	return NewSetFlag(set, time.ParseDuration, time.Duration.String)
+}
+
+// Set implements [flag.Value].
+// It splits value by commas and adds each non-empty, space-trimmed item to the set.
+// If any item cannot be parsed, no items are added.
+func (f *SetFlag[E]) Set(value string) error {
+	items := strings.Split(value, ",")
+	els := make([]E, 0, len(items))
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		el, err := f.parseItem(item)
+		if err != nil {
+			return err
+		}
+		els = append(els, el)
+	}
+	set := f.orderedSet()
+	for _, el := range els {
+		set.Append(el)
+	}
+	return nil
+}
+
+// String implements [flag.Value].
+// It renders the elements as a comma-separated list in insertion order.
+func (f *SetFlag[E]) String() string {
+	if f == nil || f.set == nil {
+		return ""
+	}
+	var b strings.Builder
+	for el := range f.set.Values() {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(f.formatItem(el))
+	}
+	return b.String()
+}
+
+// Get implements [flag.Getter].
+// It returns the underlying *[OrderedSet].
+func (f *SetFlag[E]) Get() any {
+	return f.orderedSet()
+}
+
+// orderedSet returns the underlying set, creating it for the zero value.
+func (f *SetFlag[E]) orderedSet() *OrderedSet[E] {
+	if f.set == nil {
+		f.set = NewOrderedSet[E]()
+	}
+	return f.set
+}
+
+func (f *SetFlag[E]) parseItem(item string) (E, error) {
+	if f.parse != nil {
+		return f.parse(item)
+	}
+	var el E
+	switch p := any(&el).(type) {
+	case *string:
+		*p = item
+		return el, nil
+	case encoding.TextUnmarshaler:
+		err := p.UnmarshalText([]byte(item))
+		return el, err
+	default:
+		return el, fmt.Errorf("%w: %T", ErrNoSetFlagParser, el)
+	}
+}
+
+func (f *SetFlag[E]) formatItem(el E) string {
+	if f.format != nil {
+		return f.format(el)
+	}
+	return fmt.Sprint(el)
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+// It adds the values in text to the set as [SetFlag.Set] does.
+func (f *SetFlag[E]) UnmarshalText(text []byte) error {
+	return f.Set(string(text))
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+// It returns the same form as [SetFlag.String].
+func (f *SetFlag[E]) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
@@ -0,0 +1,116 @@
+package coll_test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"net/netip"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aereal/coll"
+)
+
+func TestStringSetFlag(t *testing.T) {
+	tags := coll.NewOrderedSet[string]()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(coll.StringSetFlag(tags), "tag", "tags")
+	if err := fs.Parse([]string{"-tag", "b", "-tag", "a, c,,b", "-tag", "d"}); err != nil {
+		t.Fatal(err)
+	}
+	got := slices.Collect(tags.Values())
+	want := []string{"b", "a", "c", "d"}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if got, want := fs.Lookup("tag").Value.String(), "b,a,c,d"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := fs.Lookup("tag").Value.(flag.Getter).Get(); got != tags {
+		t.Errorf("Get() returns unexpected value: %#v", got)
+	}
+}
+
+func TestIntSetFlag(t *testing.T) {
+	nums := coll.NewOrderedSet[int]()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(coll.IntSetFlag(nums), "shard", "shards")
+	if err := fs.Parse([]string{"-shard", "3,1", "-shard", "3"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := slices.Collect(nums.Values()), []int{3, 1}; !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if err := fs.Parse([]string{"-shard", "4,x"}); err == nil {
+		t.Error("expected an error for an invalid integer")
+	}
+	if got, want := slices.Collect(nums.Values()), []int{3, 1}; !reflect.DeepEqual(want, got) {
+		t.Errorf("the invalid flag value modified the set:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+func TestDurationSetFlag(t *testing.T) {
+	durations := coll.NewOrderedSet[time.Duration]()
+	f := coll.DurationSetFlag(durations)
+	if err := f.UnmarshalText([]byte("1m,30s")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.UnmarshalText([]byte("60s")); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := slices.Collect(durations.Values()), []time.Duration{time.Minute, 30 * time.Second}; !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	text, err := f.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(text), "1m0s,30s"; got != want {
+		t.Errorf("MarshalText() = %q, want %q", got, want)
+	}
+}
+
+func TestSetFlag_zeroValue(t *testing.T) {
+	var f coll.SetFlag[string]
+	if got := f.String(); got != "" {
+		t.Errorf("String() = %q, want empty", got)
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&f, "tag", "tags")
+	if err := fs.Parse([]string{"-tag", "b,a", "-tag", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	tags, ok := f.Get().(*coll.OrderedSet[string])
+	if !ok {
+		t.Fatalf("Get() returns unexpected value: %#v", f.Get())
+	}
+	if got, want := slices.Collect(tags.Values()), []string{"b", "a"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if got, want := f.String(), "b,a"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestSetFlag_zeroValueTextUnmarshaler(t *testing.T) {
+	var f coll.SetFlag[netip.Addr]
+	if err := f.Set("192.0.2.1,192.0.2.2"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.String(), "192.0.2.1,192.0.2.2"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if err := f.Set("192.0.2.3,x"); err == nil {
+		t.Error("expected an error for an invalid address")
+	}
+}
+
+func TestSetFlag_zeroValueWithoutParser(t *testing.T) {
+	var f coll.SetFlag[int]
+	if err := f.Set("1"); !errors.Is(err, coll.ErrNoSetFlagParser) {
+		t.Errorf("Set() returns unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,498 @@
+package coll
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// String returns the elements of the set such as Set{a, b, c}.
+// If the element type is an ordered type (integers, floats and strings), the elements are sorted so that the output is deterministic.
+func (s *Set[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+// GoString returns a Go expression that constructs an equivalent set, such as coll.NewSet("a", "b").
+func (s *Set[E]) GoString() string {
+	return goStringSet("NewSet", sortedIfOrdered(slices.Collect(s.Values())))
+}
+
+// Format implements [fmt.Formatter].
+// The %#v verb prints [Set.GoString], and the other verbs are applied to each element, such as Set{"a", "b"} for %q.
+func (s *Set[E]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, s.GoString())
+		return
+	}
+	formatSet(f, verb, "Set", sortedIfOrdered(slices.Collect(s.Values())))
+}
+
+// String returns the elements of the set in insertion order, such as OrderedSet{c, a, b}.
+func (s *OrderedSet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+// GoString returns a Go expression that constructs an equivalent set, such as coll.NewOrderedSet("c", "a").
+func (s *OrderedSet[E]) GoString() string {
+	return goStringSet("NewOrderedSet", slices.Collect(s.Values()))
+}
+
+// Format implements [fmt.Formatter].
+// The %#v verb prints [OrderedSet.GoString], and the other verbs are applied to each element, such as OrderedSet{"c", "a"} for %q.
+func (s *OrderedSet[E]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, s.GoString())
+		return
+	}
+	formatSet(f, verb, "OrderedSet", slices.Collect(s.Values()))
+}
+
+// String returns the entries of the map in insertion order, such as OrderedMap{a: 1, b: 2}.
+func (m *OrderedMap[K, V]) String() string {
+	return fmt.Sprint(m)
+}
+
+// GoString returns a Go expression that constructs an equivalent map.
+// Since there is no constructor that takes entries, the expression is an immediately invoked function literal such as
+// func() *coll.OrderedMap[string, int] { m := coll.NewOrderedMap[string, int](); m.Put("a", 1); return m }().
+func (m *OrderedMap[K, V]) GoString() string {
+	return goStringOrderedMap("OrderedMap", "", m.All())
+}
+
+// Format implements [fmt.Formatter].
+// The %#v verb prints [OrderedMap.GoString], and the other verbs are applied to each key and value, such as OrderedMap{"a": "x"} for %q.
+func (m *OrderedMap[K, V]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, m.GoString())
+		return
+	}
+	formatOrderedMap(f, verb, "OrderedMap", m.All())
+}
+
+// String returns the elements of the set such as UnsyncSet{a, b, c}, sorted in the same way as [Set.String].
+func (s *UnsyncSet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+// GoString returns a Go expression that constructs an equivalent set, such as coll.NewUnsyncSet("a", "b").
+func (s *UnsyncSet[E]) GoString() string {
+	return goStringSet("NewUnsyncSet", sortedIfOrdered(slices.Collect(s.Values())))
+}
+
+// Format implements [fmt.Formatter] in the same way as [Set.Format].
+func (s *UnsyncSet[E]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, s.GoString())
+		return
+	}
+	formatSet(f, verb, "UnsyncSet", sortedIfOrdered(slices.Collect(s.Values())))
+}
+
+// String returns the elements of the set in insertion order, such as UnsyncOrderedSet{c, a, b}.
+func (s *UnsyncOrderedSet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+// GoString returns a Go expression that constructs an equivalent set, such as coll.NewUnsyncOrderedSet("c", "a").
+func (s *UnsyncOrderedSet[E]) GoString() string {
+	return goStringSet("NewUnsyncOrderedSet", slices.Collect(s.Values()))
+}
+
+// Format implements [fmt.Formatter] in the same way as [OrderedSet.Format].
+func (s *UnsyncOrderedSet[E]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, s.GoString())
+		return
+	}
+	formatSet(f, verb, "UnsyncOrderedSet", slices.Collect(s.Values()))
+}
+
+// String returns the entries of the map in insertion order, such as UnsyncOrderedMap{a: 1, b: 2}.
+func (m *UnsyncOrderedMap[K, V]) String() string {
+	return fmt.Sprint(m)
+}
+
+// GoString returns a Go expression that constructs an equivalent map in the same form as [OrderedMap.GoString].
+func (m *UnsyncOrderedMap[K, V]) GoString() string {
+	return goStringOrderedMap("UnsyncOrderedMap", "", m.All())
+}
+
+// Format implements [fmt.Formatter] in the same way as [OrderedMap.Format].
+func (m *UnsyncOrderedMap[K, V]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, m.GoString())
+		return
+	}
+	formatOrderedMap(f, verb, "UnsyncOrderedMap", m.All())
+}
+
+// String returns the elements of the set in ascending order, such as SortedSet{a, b, c}.
+func (s *SortedSet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+// GoString returns a Go expression that constructs an equivalent set, such as coll.NewSortedSet("a", "b").
+func (s *SortedSet[E]) GoString() string {
+	return goStringSet("NewSortedSet", slices.Collect(s.Values()))
+}
+
+// Format implements [fmt.Formatter] in the same way as [OrderedSet.Format].
+func (s *SortedSet[E]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, s.GoString())
+		return
+	}
+	formatSet(f, verb, "SortedSet", slices.Collect(s.Values()))
+}
+
+// String returns the elements of the set in the order of the comparison function, such as SortedSetFunc{a, b, c}.
+func (s *SortedSetFunc[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+// Format implements [fmt.Formatter].
+// Every verb is applied to each element, such as SortedSetFunc{"a", "b"} for %q.
+// Since the comparison function cannot be printed, there is no Go syntax representation and %#v formats each element with %#v.
+func (s *SortedSetFunc[E]) Format(f fmt.State, verb rune) {
+	formatSet(f, verb, "SortedSetFunc", slices.Collect(s.Values()))
+}
+
+// String returns the entries of the map in ascending order of the keys, such as SortedMap{a: 1, b: 2}.
+func (m *SortedMap[K, V]) String() string {
+	return fmt.Sprint(m)
+}
+
+// GoString returns a Go expression that constructs an equivalent map in the same form as [OrderedMap.GoString].
+func (m *SortedMap[K, V]) GoString() string {
+	return goStringOrderedMap("SortedMap", "", m.All())
+}
+
+// Format implements [fmt.Formatter] in the same way as [OrderedMap.Format].
+func (m *SortedMap[K, V]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, m.GoString())
+		return
+	}
+	formatOrderedMap(f, verb, "SortedMap", m.All())
+}
+
+// String returns the entries of the map in the order of the comparison function, such as SortedMapFunc{a: 1, b: 2}.
+func (m *SortedMapFunc[K, V]) String() string {
+	return fmt.Sprint(m)
+}
+
+// Format implements [fmt.Formatter] in the same way as [SortedSetFunc.Format], applying every verb to each key and value.
+func (m *SortedMapFunc[K, V]) Format(f fmt.State, verb rune) {
+	formatOrderedMap(f, verb, "SortedMapFunc", m.All())
+}
+
+// String returns the elements of the set such as ShardedSet{a, b, c}, sorted in the same way as [Set.String].
+func (s *ShardedSet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+// GoString returns a Go expression that constructs an equivalent set with the same number of shards.
+// Since there is no constructor that takes elements, the expression is an immediately invoked function literal such as
+// func() *coll.ShardedSet[string] { s := coll.NewShardedSet[string](8); s.Append("a"); return s }().
+func (s *ShardedSet[E]) GoString() string {
+	defer s.rlockAll()()
+	els := make([]E, 0)
+	for i := range s.shards {
+		els = slices.AppendSeq(els, s.shards[i].set.Values())
+	}
+	typeArgs := "[" + reflect.TypeFor[E]().String() + "]"
+	var b strings.Builder
+	fmt.Fprintf(&b, "func() *coll.ShardedSet%s { s := coll.NewShardedSet%s(%d); ", typeArgs, typeArgs, len(s.shards))
+	for _, el := range sortedIfOrdered(els) {
+		fmt.Fprintf(&b, "s.Append(%#v); ", el)
+	}
+	b.WriteString("return s }()")
+	return b.String()
+}
+
+// Format implements [fmt.Formatter] in the same way as [Set.Format].
+func (s *ShardedSet[E]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, s.GoString())
+		return
+	}
+	formatSet(f, verb, "ShardedSet", sortedIfOrdered(slices.Collect(s.Values())))
+}
+
+// String returns the entries of the map in insertion order, such as ShardedOrderedMap{a: 1, b: 2}.
+func (m *ShardedOrderedMap[K, V]) String() string {
+	return fmt.Sprint(m)
+}
+
+// GoString returns a Go expression that constructs an equivalent map with the same number of shards,
+// in the same form as [OrderedMap.GoString].
+func (m *ShardedOrderedMap[K, V]) GoString() string {
+	defer m.rlockAll()()
+	all := func(yield func(K, V) bool) {
+		for e := range m.unsafeAll() {
+			if !yield(e.value.key, e.value.value.value) {
+				return
+			}
+		}
+	}
+	return goStringOrderedMap("ShardedOrderedMap", strconv.Itoa(len(m.shards)), all)
+}
+
+// Format implements [fmt.Formatter] in the same way as [OrderedMap.Format].
+func (m *ShardedOrderedMap[K, V]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, m.GoString())
+		return
+	}
+	formatOrderedMap(f, verb, "ShardedOrderedMap", m.All())
+}
+
+// String returns the elements of the current snapshot such as CopyOnWriteSet{a, b, c}, sorted in the same way as [Set.String].
+func (s *CopyOnWriteSet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+// GoString returns a Go expression that constructs an equivalent set, such as coll.NewCopyOnWriteSet("a", "b").
+func (s *CopyOnWriteSet[E]) GoString() string {
+	return goStringSet("NewCopyOnWriteSet", sortedIfOrdered(slices.Collect(s.Values())))
+}
+
+// Format implements [fmt.Formatter] in the same way as [Set.Format].
+func (s *CopyOnWriteSet[E]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, s.GoString())
+		return
+	}
+	formatSet(f, verb, "CopyOnWriteSet", sortedIfOrdered(slices.Collect(s.Values())))
+}
+
+// String returns the elements of the set such as PersistentSet{a, b, c}, sorted in the same way as [Set.String].
+func (s *PersistentSet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+// GoString returns a Go expression that constructs an equivalent set, such as coll.NewPersistentSet("a", "b").
+func (s *PersistentSet[E]) GoString() string {
+	return goStringSet("NewPersistentSet", sortedIfOrdered(slices.Collect(s.Values())))
+}
+
+// Format implements [fmt.Formatter] in the same way as [Set.Format].
+func (s *PersistentSet[E]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, s.GoString())
+		return
+	}
+	formatSet(f, verb, "PersistentSet", sortedIfOrdered(slices.Collect(s.Values())))
+}
+
+// String returns the elements of the set in insertion order, such as PersistentOrderedSet{c, a, b}.
+func (s *PersistentOrderedSet[E]) String() string {
+	return fmt.Sprint(s)
+}
+
+// GoString returns a Go expression that constructs an equivalent set, such as coll.NewPersistentOrderedSet("c", "a").
+func (s *PersistentOrderedSet[E]) GoString() string {
+	return goStringSet("NewPersistentOrderedSet", slices.Collect(s.Values()))
+}
+
+// Format implements [fmt.Formatter] in the same way as [OrderedSet.Format].
+func (s *PersistentOrderedSet[E]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, s.GoString())
+		return
+	}
+	formatSet(f, verb, "PersistentOrderedSet", slices.Collect(s.Values()))
+}
+
+// String returns the entries of the map in insertion order, such as PersistentOrderedMap{a: 1, b: 2}.
+func (m *PersistentOrderedMap[K, V]) String() string {
+	return fmt.Sprint(m)
+}
+
+// GoString returns a Go expression that constructs an equivalent map by chaining [PersistentOrderedMap.With],
+// such as coll.NewPersistentOrderedMap[string, int]().With("a", 1).
+func (m *PersistentOrderedMap[K, V]) GoString() string {
+	var b strings.Builder
+	b.WriteString("coll.NewPersistentOrderedMap[" + reflect.TypeFor[K]().String() + ", " + reflect.TypeFor[V]().String() + "]()")
+	for key, value := range m.All() {
+		fmt.Fprintf(&b, ".With(%#v, %#v)", key, value)
+	}
+	return b.String()
+}
+
+// Format implements [fmt.Formatter] in the same way as [OrderedMap.Format].
+func (m *PersistentOrderedMap[K, V]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, m.GoString())
+		return
+	}
+	formatOrderedMap(f, verb, "PersistentOrderedMap", m.All())
+}
+
+// String returns the elements of the set in ascending order, such as BitSet{1, 5, 64}.
+func (s *BitSet) String() string {
+	return fmt.Sprint(s)
+}
+
+// GoString returns a Go expression that constructs an equivalent set, such as coll.NewBitSet(1, 5, 64).
+func (s *BitSet) GoString() string {
+	var els []string
+	for el := range s.Values() {
+		els = append(els, strconv.FormatUint(uint64(el), 10))
+	}
+	return "coll.NewBitSet(" + strings.Join(els, ", ") + ")"
+}
+
+// Format implements [fmt.Formatter] in the same way as [OrderedSet.Format].
+func (s *BitSet) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, s.GoString())
+		return
+	}
+	formatSet(f, verb, "BitSet", slices.Collect(s.Values()))
+}
+
+// String returns the elements of the underlying set such as SetView{a, b, c}.
+// They are in the order of the underlying set if it is ordered, and sorted in the same way as [Set.String] otherwise.
+func (v SetView[E]) String() string {
+	return fmt.Sprint(v)
+}
+
+// GoString returns a Go expression that constructs a view of an equivalent set, such as coll.NewSet("a", "b").ReadOnly(),
+// using [OrderedSet] if the underlying set is ordered and [Set] otherwise.
+func (v SetView[E]) GoString() string {
+	if isOrdered[E](v) {
+		return goStringSet("NewOrderedSet", slices.Collect(v.Values())) + ".ReadOnly()"
+	}
+	return goStringSet("NewSet", sortedIfOrdered(slices.Collect(v.Values()))) + ".ReadOnly()"
+}
+
+// Format implements [fmt.Formatter] in the same way as [Set.Format], keeping the order of an ordered underlying set.
+func (v SetView[E]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, v.GoString())
+		return
+	}
+	els := slices.Collect(v.Values())
+	if !isOrdered[E](v) {
+		els = sortedIfOrdered(els)
+	}
+	formatSet(f, verb, "SetView", els)
+}
+
+// String returns the entries of the underlying map in its order, such as MapView{a: 1, b: 2}.
+func (v MapView[K, V]) String() string {
+	return fmt.Sprint(v)
+}
+
+// GoString returns a Go expression that constructs a view of an equivalent [OrderedMap] in the form of
+// [OrderedMap.GoString] followed by .ReadOnly().
+func (v MapView[K, V]) GoString() string {
+	return goStringOrderedMap("OrderedMap", "", v.All()) + ".ReadOnly()"
+}
+
+// Format implements [fmt.Formatter] in the same way as [OrderedMap.Format].
+func (v MapView[K, V]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, v.GoString())
+		return
+	}
+	formatOrderedMap(f, verb, "MapView", v.All())
+}
+
+func formatOrderedMap[K comparable, V any](f fmt.State, verb rune, name string, all iter.Seq2[K, V]) {
+	directive := fmt.FormatString(f, verb)
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte('{')
+	first := true
+	for key, value := range all {
+		if !first {
+			b.WriteString(", ")
+		}
+		first = false
+		fmt.Fprintf(&b, directive+": "+directive, key, value)
+	}
+	b.WriteByte('}')
+	_, _ = io.WriteString(f, b.String())
+}
+
+// goStringOrderedMap returns an immediately invoked function literal that creates the map by its constructor with args
+// and puts every entry of all.
+func goStringOrderedMap[K comparable, V any](name, args string, all iter.Seq2[K, V]) string {
+	typeArgs := "[" + reflect.TypeFor[K]().String() + ", " + reflect.TypeFor[V]().String() + "]"
+	var b strings.Builder
+	b.WriteString("func() *coll." + name + typeArgs + " { m := coll.New" + name + typeArgs + "(" + args + "); ")
+	for key, value := range all {
+		fmt.Fprintf(&b, "m.Put(%#v, %#v); ", key, value)
+	}
+	b.WriteString("return m }()")
+	return b.String()
+}
+
+func formatSet[E comparable](f fmt.State, verb rune, name string, els []E) {
+	directive := fmt.FormatString(f, verb)
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte('{')
+	for i, el := range els {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, directive, el)
+	}
+	b.WriteByte('}')
+	_, _ = io.WriteString(f, b.String())
+}
+
+func goStringSet[E comparable](constructor string, els []E) string {
+	var b strings.Builder
+	b.WriteString("coll.")
+	b.WriteString(constructor)
+	// the constants printed by %#v only let the compiler infer string, int and bool; other types need an explicit type argument
+	typ := reflect.TypeFor[E]()
+	inferable := typ == reflect.TypeFor[string]() || typ == reflect.TypeFor[int]() || typ == reflect.TypeFor[bool]()
+	if len(els) == 0 || !inferable {
+		b.WriteString("[" + typ.String() + "]")
+	}
+	b.WriteByte('(')
+	for i, el := range els {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%#v", el)
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// sortedIfOrdered sorts els in ascending order if the element type is an integer, float or string type.
+func sortedIfOrdered[E comparable](els []E) []E {
+	if compare := orderedCompare[E](); compare != nil {
+		slices.SortFunc(els, compare)
+	}
+	return els
+}
+
+// orderedCompare returns the function that compares values of an integer, float or string type, or nil for the other types.
+func orderedCompare[E any]() func(a, b E) int {
+	var compare func(a, b reflect.Value) int
+	switch reflect.TypeFor[E]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		compare = func(a, b reflect.Value) int { return cmp.Compare(a.Int(), b.Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		compare = func(a, b reflect.Value) int { return cmp.Compare(a.Uint(), b.Uint()) }
+	case reflect.Float32, reflect.Float64:
+		compare = func(a, b reflect.Value) int { return cmp.Compare(a.Float(), b.Float()) }
+	case reflect.String:
+		compare = func(a, b reflect.Value) int { return cmp.Compare(a.String(), b.String()) }
+	default:
+		return nil
+	}
+	return func(a, b E) int { return compare(reflect.ValueOf(a), reflect.ValueOf(b)) }
+}
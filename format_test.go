@@ -0,0 +1,178 @@
+package coll_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aereal/coll"
+)
+
+func TestSet_Format(t *testing.T) {
+	testCases := []struct {
+		value  any
+		name   string
+		format string
+		want   string
+	}{
+		{name: "strings are sorted", value: coll.NewSet("c", "a", "b"), format: "%v", want: "Set{a, b, c}"},
+		{name: "integers are sorted", value: coll.NewSet(10, -1, 2), format: "%v", want: "Set{-1, 2, 10}"},
+		{name: "floats are sorted", value: coll.NewSet(1.5, 0.25), format: "%v", want: "Set{0.25, 1.5}"},
+		{name: "verb applies to elements", value: coll.NewSet("b", "a"), format: "%q", want: `Set{"a", "b"}`},
+		{name: "width applies to elements", value: coll.NewSet(2, 1), format: "%03d", want: "Set{001, 002}"},
+		{name: "empty", value: coll.NewSet[string](), format: "%v", want: "Set{}"},
+		{name: "Go syntax", value: coll.NewSet("b", "a"), format: "%#v", want: `coll.NewSet("a", "b")`},
+		{name: "Go syntax with typed elements", value: coll.NewSet[int64](2, 1), format: "%#v", want: `coll.NewSet[int64](1, 2)`},
+		{name: "Go syntax of empty set", value: coll.NewSet[string](), format: "%#v", want: `coll.NewSet[string]()`},
+		{name: "nil", value: (*coll.Set[string])(nil), format: "%v", want: "<nil>"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tc.format, tc.value); got != tc.want {
+				t.Errorf("mismatch:\n\twant: %s\n\t got: %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestOrderedSet_Format(t *testing.T) {
+	testCases := []struct {
+		value  any
+		name   string
+		format string
+		want   string
+	}{
+		{name: "insertion order", value: coll.NewOrderedSet("c", "a", "b"), format: "%v", want: "OrderedSet{c, a, b}"},
+		{name: "verb applies to elements", value: coll.NewOrderedSet("b", "a"), format: "%q", want: `OrderedSet{"b", "a"}`},
+		{name: "Go syntax", value: coll.NewOrderedSet("b", "a"), format: "%#v", want: `coll.NewOrderedSet("b", "a")`},
+		{name: "Go syntax with typed elements", value: coll.NewOrderedSet(time.Second), format: "%#v", want: `coll.NewOrderedSet[time.Duration](1000000000)`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tc.format, tc.value); got != tc.want {
+				t.Errorf("mismatch:\n\twant: %s\n\t got: %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestOrderedMap_Format(t *testing.T) {
+	m := coll.NewOrderedMap[string, int]()
+	m.Put("b", 2)
+	m.Put("a", 1)
+	testCases := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{name: "insertion order", format: "%v", want: "OrderedMap{b: 2, a: 1}"},
+		{name: "verb applies to keys and values", format: "%q", want: `OrderedMap{"b": '\x02', "a": '\x01'}`},
+		{name: "Go syntax", format: "%#v", want: `func() *coll.OrderedMap[string, int] { m := coll.NewOrderedMap[string, int](); m.Put("b", 2); m.Put("a", 1); return m }()`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tc.format, m); got != tc.want {
+				t.Errorf("mismatch:\n\twant: %s\n\t got: %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSet_String(t *testing.T) {
+	if got, want := coll.NewSet("b", "a").String(), "Set{a, b}"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := coll.NewOrderedSet("b", "a").String(), "OrderedSet{b, a}"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	m := coll.NewOrderedMap[string, *coll.OrderedSet[int]]()
+	m.Put("x", coll.NewOrderedSet(2, 1))
+	if got, want := m.String(), "OrderedMap{x: OrderedSet{2, 1}}"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestUnsync_Format(t *testing.T) {
+	m := coll.NewUnsyncOrderedMap[string, int]()
+	m.Put("b", 2)
+	m.Put("a", 1)
+	testCases := []struct {
+		value  any
+		name   string
+		format string
+		want   string
+	}{
+		{name: "UnsyncSet", value: coll.NewUnsyncSet("c", "a", "b"), format: "%v", want: "UnsyncSet{a, b, c}"},
+		{name: "UnsyncSet Go syntax", value: coll.NewUnsyncSet("b", "a"), format: "%#v", want: `coll.NewUnsyncSet("a", "b")`},
+		{name: "UnsyncOrderedSet", value: coll.NewUnsyncOrderedSet("c", "a", "b"), format: "%q", want: `UnsyncOrderedSet{"c", "a", "b"}`},
+		{name: "UnsyncOrderedSet Go syntax", value: coll.NewUnsyncOrderedSet[int](), format: "%#v", want: `coll.NewUnsyncOrderedSet[int]()`},
+		{name: "UnsyncOrderedMap", value: m, format: "%v", want: "UnsyncOrderedMap{b: 2, a: 1}"},
+		{name: "UnsyncOrderedMap Go syntax", value: m, format: "%#v", want: `func() *coll.UnsyncOrderedMap[string, int] { m := coll.NewUnsyncOrderedMap[string, int](); m.Put("b", 2); m.Put("a", 1); return m }()`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tc.format, tc.value); got != tc.want {
+				t.Errorf("mismatch:\n\twant: %s\n\t got: %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFormat_otherCollections(t *testing.T) {
+	sortedMap := coll.NewSortedMap[string, int]()
+	sortedMap.Put("b", 2)
+	sortedMap.Put("a", 1)
+	sortedMapFunc := coll.NewSortedMapFunc[string, int](func(a, b string) int { return strings.Compare(b, a) })
+	sortedMapFunc.Put("a", 1)
+	sortedMapFunc.Put("b", 2)
+	shardedSet := coll.NewShardedSet[string](2)
+	shardedSet.Append("b")
+	shardedSet.Append("a")
+	shardedMap := coll.NewShardedOrderedMap[string, int](2)
+	shardedMap.Put("b", 2)
+	shardedMap.Put("a", 1)
+	orderedMap := coll.NewOrderedMap[string, int]()
+	orderedMap.Put("b", 2)
+	testCases := []struct {
+		value  any
+		name   string
+		format string
+		want   string
+	}{
+		{name: "SortedSet", value: coll.NewSortedSet("c", "a", "b"), format: "%q", want: `SortedSet{"a", "b", "c"}`},
+		{name: "SortedSet Go syntax", value: coll.NewSortedSet(2, 1), format: "%#v", want: `coll.NewSortedSet(1, 2)`},
+		{name: "SortedSetFunc", value: coll.NewSortedSetFunc(strings.Compare, "b", "a"), format: "%v", want: "SortedSetFunc{a, b}"},
+		{name: "SortedSetFunc Go syntax", value: coll.NewSortedSetFunc(strings.Compare, "b", "a"), format: "%#v", want: `SortedSetFunc{"a", "b"}`},
+		{name: "SortedMap", value: sortedMap, format: "%v", want: "SortedMap{a: 1, b: 2}"},
+		{name: "SortedMap Go syntax", value: sortedMap, format: "%#v", want: `func() *coll.SortedMap[string, int] { m := coll.NewSortedMap[string, int](); m.Put("a", 1); m.Put("b", 2); return m }()`},
+		{name: "SortedMapFunc", value: sortedMapFunc, format: "%v", want: "SortedMapFunc{b: 2, a: 1}"},
+		{name: "ShardedSet", value: shardedSet, format: "%v", want: "ShardedSet{a, b}"},
+		{name: "ShardedSet Go syntax", value: shardedSet, format: "%#v", want: `func() *coll.ShardedSet[string] { s := coll.NewShardedSet[string](2); s.Append("a"); s.Append("b"); return s }()`},
+		{name: "ShardedOrderedMap", value: shardedMap, format: "%v", want: "ShardedOrderedMap{b: 2, a: 1}"},
+		{name: "ShardedOrderedMap Go syntax", value: shardedMap, format: "%#v", want: `func() *coll.ShardedOrderedMap[string, int] { m := coll.NewShardedOrderedMap[string, int](2); m.Put("b", 2); m.Put("a", 1); return m }()`},
+		{name: "CopyOnWriteSet", value: coll.NewCopyOnWriteSet(3, 1, 2), format: "%v", want: "CopyOnWriteSet{1, 2, 3}"},
+		{name: "CopyOnWriteSet Go syntax", value: coll.NewCopyOnWriteSet(3, 1, 2), format: "%#v", want: `coll.NewCopyOnWriteSet(1, 2, 3)`},
+		{name: "PersistentSet", value: coll.NewPersistentSet("c", "a", "b"), format: "%v", want: "PersistentSet{a, b, c}"},
+		{name: "PersistentSet Go syntax", value: coll.NewPersistentSet("b", "a"), format: "%#v", want: `coll.NewPersistentSet("a", "b")`},
+		{name: "PersistentOrderedSet", value: coll.NewPersistentOrderedSet("c", "a", "b"), format: "%v", want: "PersistentOrderedSet{c, a, b}"},
+		{name: "PersistentOrderedSet Go syntax", value: coll.NewPersistentOrderedSet("b", "a"), format: "%#v", want: `coll.NewPersistentOrderedSet("b", "a")`},
+		{name: "PersistentOrderedMap", value: coll.NewPersistentOrderedMap[string, int]().With("b", 2).With("a", 1), format: "%v", want: "PersistentOrderedMap{b: 2, a: 1}"},
+		{name: "PersistentOrderedMap Go syntax", value: coll.NewPersistentOrderedMap[string, int]().With("b", 2), format: "%#v", want: `coll.NewPersistentOrderedMap[string, int]().With("b", 2)`},
+		{name: "BitSet", value: coll.NewBitSet(64, 1, 5), format: "%v", want: "BitSet{1, 5, 64}"},
+		{name: "BitSet Go syntax", value: coll.NewBitSet(64, 1), format: "%#v", want: `coll.NewBitSet(1, 64)`},
+		{name: "BitSet Go syntax of empty set", value: coll.NewBitSet(), format: "%#v", want: `coll.NewBitSet()`},
+		{name: "SetView", value: coll.NewSet("b", "a").ReadOnly(), format: "%v", want: "SetView{a, b}"},
+		{name: "SetView of ordered set", value: coll.NewOrderedSet("b", "a").ReadOnly(), format: "%#v", want: `coll.NewOrderedSet("b", "a").ReadOnly()`},
+		{name: "zero SetView", value: coll.SetView[int]{}, format: "%v", want: "SetView{}"},
+		{name: "MapView", value: orderedMap.ReadOnly(), format: "%v", want: "MapView{b: 2}"},
+		{name: "MapView Go syntax", value: orderedMap.ReadOnly(), format: "%#v", want: `func() *coll.OrderedMap[string, int] { m := coll.NewOrderedMap[string, int](); m.Put("b", 2); return m }().ReadOnly()`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tc.format, tc.value); got != tc.want {
+				t.Errorf("mismatch:\n\twant: %s\n\t got: %s", tc.want, got)
+			}
+		})
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/aereal/coll
 
-go 1.23.8
+go 1.24.0
@@ -0,0 +1,237 @@
+package coll
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrInvalidBitSetData is returned when data cannot be decoded as a [BitSet].
+var ErrInvalidBitSetData = errors.New("invalid BitSet data")
+
+// GobEncode implements [gob.GobEncoder].
+// The elements are encoded as a slice.
+func (s *Set[E]) GobEncode() ([]byte, error) {
+	return gobEncode(slices.Collect(s.Values()))
+}
+
+// GobDecode implements [gob.GobDecoder].
+// It replaces the elements of the set with the decoded ones.
+func (s *Set[E]) GobDecode(data []byte) error {
+	var els []E
+	if err := gobDecode(data, &els); err != nil {
+		return err
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.unsync.replace(els)
+	return nil
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler] in the same format as [Set.GobEncode].
+func (s *Set[E]) MarshalBinary() ([]byte, error) {
+	return s.GobEncode()
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler] in the same format as [Set.GobDecode].
+func (s *Set[E]) UnmarshalBinary(data []byte) error {
+	return s.GobDecode(data)
+}
+
+// GobEncode implements [gob.GobEncoder].
+// The elements are encoded as a slice in insertion order.
+func (s *OrderedSet[E]) GobEncode() ([]byte, error) {
+	return gobEncode(slices.Collect(s.Values()))
+}
+
+// GobDecode implements [gob.GobDecoder].
+// It replaces the elements of the set with the decoded ones, keeping their order.
+func (s *OrderedSet[E]) GobDecode(data []byte) error {
+	var els []E
+	if err := gobDecode(data, &els); err != nil {
+		return err
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.unsync.replace(els)
+	return nil
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler] in the same format as [OrderedSet.GobEncode].
+func (s *OrderedSet[E]) MarshalBinary() ([]byte, error) {
+	return s.GobEncode()
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler] in the same format as [OrderedSet.GobDecode].
+func (s *OrderedSet[E]) UnmarshalBinary(data []byte) error {
+	return s.GobDecode(data)
+}
+
+// gobOrderedMap is the wire representation of OrderedMap.
+type gobOrderedMap[K comparable, V any] struct {
+	Keys   []K
+	Values []V
+}
+
+// GobEncode implements [gob.GobEncoder].
+// The keys and the values are encoded as slices in insertion order.
+func (m *OrderedMap[K, V]) GobEncode() ([]byte, error) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return m.unsync.GobEncode()
+}
+
+// GobDecode implements [gob.GobDecoder].
+// It replaces the entries of the map with the decoded ones, keeping their order.
+func (m *OrderedMap[K, V]) GobDecode(data []byte) error {
+	var wire gobOrderedMap[K, V]
+	if err := gobDecode(data, &wire); err != nil {
+		return err
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.unsync.replace(wire.Keys, wire.Values)
+	return nil
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler] in the same format as [OrderedMap.GobEncode].
+func (m *OrderedMap[K, V]) MarshalBinary() ([]byte, error) {
+	return m.GobEncode()
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler] in the same format as [OrderedMap.GobDecode].
+func (m *OrderedMap[K, V]) UnmarshalBinary(data []byte) error {
+	return m.GobDecode(data)
+}
+
+// GobEncode implements [gob.GobEncoder] in the same format as [Set.GobEncode].
+func (s *UnsyncSet[E]) GobEncode() ([]byte, error) {
+	return gobEncode(slices.Collect(s.Values()))
+}
+
+// GobDecode implements [gob.GobDecoder] in the same way as [Set.GobDecode].
+func (s *UnsyncSet[E]) GobDecode(data []byte) error {
+	var els []E
+	if err := gobDecode(data, &els); err != nil {
+		return err
+	}
+	s.replace(els)
+	return nil
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler] in the same format as [UnsyncSet.GobEncode].
+func (s *UnsyncSet[E]) MarshalBinary() ([]byte, error) {
+	return s.GobEncode()
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler] in the same format as [UnsyncSet.GobDecode].
+func (s *UnsyncSet[E]) UnmarshalBinary(data []byte) error {
+	return s.GobDecode(data)
+}
+
+// GobEncode implements [gob.GobEncoder] in the same format as [OrderedSet.GobEncode].
+func (s *UnsyncOrderedSet[E]) GobEncode() ([]byte, error) {
+	return gobEncode(slices.Collect(s.Values()))
+}
+
+// GobDecode implements [gob.GobDecoder] in the same way as [OrderedSet.GobDecode].
+func (s *UnsyncOrderedSet[E]) GobDecode(data []byte) error {
+	var els []E
+	if err := gobDecode(data, &els); err != nil {
+		return err
+	}
+	s.replace(els)
+	return nil
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler] in the same format as [UnsyncOrderedSet.GobEncode].
+func (s *UnsyncOrderedSet[E]) MarshalBinary() ([]byte, error) {
+	return s.GobEncode()
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler] in the same format as [UnsyncOrderedSet.GobDecode].
+func (s *UnsyncOrderedSet[E]) UnmarshalBinary(data []byte) error {
+	return s.GobDecode(data)
+}
+
+// GobEncode implements [gob.GobEncoder] in the same format as [OrderedMap.GobEncode].
+func (m *UnsyncOrderedMap[K, V]) GobEncode() ([]byte, error) {
+	wire := gobOrderedMap[K, V]{
+		Keys:   make([]K, 0, m.entries.len),
+		Values: make([]V, 0, m.entries.len),
+	}
+	for key, value := range m.All() {
+		wire.Keys = append(wire.Keys, key)
+		wire.Values = append(wire.Values, value)
+	}
+	return gobEncode(wire)
+}
+
+// GobDecode implements [gob.GobDecoder] in the same way as [OrderedMap.GobDecode].
+func (m *UnsyncOrderedMap[K, V]) GobDecode(data []byte) error {
+	var wire gobOrderedMap[K, V]
+	if err := gobDecode(data, &wire); err != nil {
+		return err
+	}
+	m.replace(wire.Keys, wire.Values)
+	return nil
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler] in the same format as [UnsyncOrderedMap.GobEncode].
+func (m *UnsyncOrderedMap[K, V]) MarshalBinary() ([]byte, error) {
+	return m.GobEncode()
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler] in the same format as [UnsyncOrderedMap.GobDecode].
+func (m *UnsyncOrderedMap[K, V]) UnmarshalBinary(data []byte) error {
+	return m.GobDecode(data)
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler].
+// The set is encoded as its words of 64 bits in little-endian order, where bit i of word w stands for the element 64*w+i.
+// Since BitSet implements MarshalBinary, [encoding/gob] uses the same format.
+func (s *BitSet) MarshalBinary() ([]byte, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	data := make([]byte, 0, len(s.words)*8)
+	for _, word := range s.words {
+		data = binary.LittleEndian.AppendUint64(data, word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler] in the same format as [BitSet.MarshalBinary].
+// It replaces the elements of the set with the decoded ones.
+// It returns [ErrInvalidBitSetData] if the length of data is not a multiple of 8,
+// or if data has more than MaxBitSetElement/64+1 words, the number of words of a set holding [MaxBitSetElement].
+func (s *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return fmt.Errorf("%w: length %d is not a multiple of 8", ErrInvalidBitSetData, len(data))
+	}
+	if len(data)/8 > maxBitSetWords {
+		return fmt.Errorf("%w: %d words exceed %d words of a set holding MaxBitSetElement", ErrInvalidBitSetData, len(data)/8, maxBitSetWords)
+	}
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.words = trimBitSetWords(words)
+	return nil
+}
+
+func gobEncode(v any) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gobDecode(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
@@ -0,0 +1,207 @@
+package coll_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func gobRoundTrip[T any](t *testing.T, input T) T {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(input); err != nil {
+		t.Fatal(err)
+	}
+	var output T
+	if err := gob.NewDecoder(buf).Decode(&output); err != nil {
+		t.Fatal(err)
+	}
+	return output
+}
+
+func TestSet_gob(t *testing.T) {
+	t.Run("elements", func(t *testing.T) {
+		got := gobRoundTrip(t, coll.NewSet(3, 1, 2))
+		if gotSlice, want := slices.Sorted(got.Values()), []int{1, 2, 3}; !reflect.DeepEqual(want, gotSlice) {
+			t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, gotSlice)
+		}
+	})
+	t.Run("zero value", func(t *testing.T) {
+		got := gobRoundTrip(t, new(coll.Set[string]))
+		if got.Len() != 0 {
+			t.Errorf("Len() returns unexpected value: %d", got.Len())
+		}
+	})
+}
+
+func TestOrderedSet_gob(t *testing.T) {
+	t.Run("elements", func(t *testing.T) {
+		got := gobRoundTrip(t, coll.NewOrderedSet("c", "a", "b"))
+		if gotSlice, want := slices.Collect(got.Values()), []string{"c", "a", "b"}; !reflect.DeepEqual(want, gotSlice) {
+			t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, gotSlice)
+		}
+	})
+	t.Run("zero value", func(t *testing.T) {
+		got := gobRoundTrip(t, new(coll.OrderedSet[string]))
+		if got.Len() != 0 {
+			t.Errorf("Len() returns unexpected value: %d", got.Len())
+		}
+	})
+}
+
+func TestOrderedMap_gob(t *testing.T) {
+	t.Run("entries", func(t *testing.T) {
+		input := coll.NewOrderedMap[string, int]()
+		input.Put("z", 1)
+		input.Put("a", 2)
+		got := gobRoundTrip(t, input)
+		if gotKeys, wantKeys := slices.Collect(got.Keys()), []string{"z", "a"}; !reflect.DeepEqual(wantKeys, gotKeys) {
+			t.Errorf("Keys: got %v, want %v", gotKeys, wantKeys)
+		}
+		if gotVals, wantVals := slices.Collect(got.Values()), []int{1, 2}; !reflect.DeepEqual(wantVals, gotVals) {
+			t.Errorf("Values: got %v, want %v", gotVals, wantVals)
+		}
+	})
+	t.Run("zero value", func(t *testing.T) {
+		got := gobRoundTrip(t, new(coll.OrderedMap[string, int]))
+		if got.Len() != 0 {
+			t.Errorf("Len() returns unexpected value: %d", got.Len())
+		}
+	})
+	t.Run("nested", func(t *testing.T) {
+		type cache struct {
+			Groups *coll.OrderedMap[string, *coll.OrderedSet[int]]
+			Seen   *coll.Set[string]
+		}
+		groups := coll.NewOrderedMap[string, *coll.OrderedSet[int]]()
+		groups.Put("b", coll.NewOrderedSet(3, 1))
+		groups.Put("a", coll.NewOrderedSet[int]())
+		got := gobRoundTrip(t, cache{Groups: groups, Seen: coll.NewSet("x")})
+
+		if gotKeys, wantKeys := slices.Collect(got.Groups.Keys()), []string{"b", "a"}; !reflect.DeepEqual(wantKeys, gotKeys) {
+			t.Errorf("Keys: got %v, want %v", gotKeys, wantKeys)
+		}
+		b, _ := got.Groups.Get("b")
+		if gotSlice, want := slices.Collect(b.Values()), []int{3, 1}; !reflect.DeepEqual(want, gotSlice) {
+			t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, gotSlice)
+		}
+		if !got.Seen.Contains("x") {
+			t.Error("the set says it DOES NOT contain 'x'")
+		}
+	})
+}
+
+func TestOrderedMap_MarshalBinary(t *testing.T) {
+	input := coll.NewOrderedMap[int, string]()
+	input.Put(2, "b")
+	input.Put(1, "a")
+	data, err := input.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := coll.NewOrderedMap[int, string]()
+	got.Put(100, "discarded")
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if gotKeys, wantKeys := slices.Collect(got.Keys()), []int{2, 1}; !reflect.DeepEqual(wantKeys, gotKeys) {
+		t.Errorf("Keys: got %v, want %v", gotKeys, wantKeys)
+	}
+}
+
+func TestOrderedSet_MarshalBinary(t *testing.T) {
+	data, err := coll.NewOrderedSet(3, 1, 2).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := new(coll.OrderedSet[int])
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if gotSlice, want := slices.Collect(got.Values()), []int{3, 1, 2}; !reflect.DeepEqual(want, gotSlice) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, gotSlice)
+	}
+	if err := got.UnmarshalBinary([]byte("broken")); err == nil {
+		t.Error("expected an error for broken data")
+	}
+}
+
+func TestSet_MarshalBinary(t *testing.T) {
+	data, err := coll.NewSet("a", "b").MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := coll.NewSet("discarded")
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if gotSlice, want := slices.Sorted(got.Values()), []string{"a", "b"}; !reflect.DeepEqual(want, gotSlice) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, gotSlice)
+	}
+}
+
+func TestUnsync_gob(t *testing.T) {
+	t.Run("UnsyncSet", func(t *testing.T) {
+		got := gobRoundTrip(t, coll.NewUnsyncSet(3, 1, 2))
+		if gotSlice, want := slices.Sorted(got.Values()), []int{1, 2, 3}; !reflect.DeepEqual(want, gotSlice) {
+			t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, gotSlice)
+		}
+	})
+	t.Run("UnsyncOrderedSet", func(t *testing.T) {
+		got := gobRoundTrip(t, coll.NewUnsyncOrderedSet("c", "a", "b"))
+		if gotSlice, want := slices.Collect(got.Values()), []string{"c", "a", "b"}; !reflect.DeepEqual(want, gotSlice) {
+			t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, gotSlice)
+		}
+	})
+	t.Run("UnsyncOrderedMap", func(t *testing.T) {
+		input := coll.NewUnsyncOrderedMap[string, int]()
+		input.Put("z", 1)
+		input.Put("a", 2)
+		got := gobRoundTrip(t, input)
+		if gotKeys, want := slices.Collect(got.Keys()), []string{"z", "a"}; !reflect.DeepEqual(want, gotKeys) {
+			t.Errorf("Keys: got %v, want %v", gotKeys, want)
+		}
+		if gotVals, want := slices.Collect(got.Values()), []int{1, 2}; !reflect.DeepEqual(want, gotVals) {
+			t.Errorf("Values: got %v, want %v", gotVals, want)
+		}
+	})
+}
+
+func TestBitSet_binary(t *testing.T) {
+	s := coll.NewBitSet(0, 63, 64, 4095)
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 4096/8 {
+		t.Errorf("encoded into %d bytes, want %d", len(data), 4096/8)
+	}
+	got := coll.NewBitSet(7)
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(s) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", slices.Collect(s.Values()), slices.Collect(got.Values()))
+	}
+	if fromGob := gobRoundTrip(t, s); !fromGob.Equal(s) {
+		t.Errorf("gob mismatch:\n\twant: %#v\n\t got: %#v", slices.Collect(s.Values()), slices.Collect(fromGob.Values()))
+	}
+	if err := got.UnmarshalBinary([]byte{1, 2, 3}); !errors.Is(err, coll.ErrInvalidBitSetData) {
+		t.Errorf("UnmarshalBinary() returns %v, want ErrInvalidBitSetData", err)
+	}
+	largest, err := coll.NewBitSet(coll.MaxBitSetElement).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := got.UnmarshalBinary(largest); err != nil || !got.Contains(coll.MaxBitSetElement) {
+		t.Errorf("UnmarshalBinary() of a set holding MaxBitSetElement returns %v", err)
+	}
+	if err := got.UnmarshalBinary(append(largest, make([]byte, 8)...)); !errors.Is(err, coll.ErrInvalidBitSetData) {
+		t.Errorf("UnmarshalBinary() of too many words returns %v, want ErrInvalidBitSetData", err)
+	}
+}
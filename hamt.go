@@ -0,0 +1,247 @@
+package coll
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// hamtSeed is shared by every trie so that all the versions derived from a trie place a key at the same position.
+var hamtSeed = maphash.MakeSeed()
+
+const (
+	hamtBits = 5
+	hamtMask = 1<<hamtBits - 1
+	// hamtHashBits is the number of bits of the hash; nodes below this depth hold colliding leaves.
+	hamtHashBits = 64
+)
+
+// hamt is a persistent hash array mapped trie.
+// Updates return a new trie that shares every untouched node with the old one, copying only the path to the updated leaf,
+// so a trie is never modified once created. The zero value is an empty trie.
+type hamt[K comparable, V any] struct {
+	root *hamtNode[K, V]
+	len  int
+}
+
+// hamtNode is a node of hamt.
+// entries are compressed by bitmap: the entry for the hash chunk c exists if bit c is set, at the index of the number of lower set bits.
+// Once the hash is exhausted, a node instead holds the leaves whose hashes collide, without a bitmap.
+type hamtNode[K comparable, V any] struct {
+	entries []hamtEntry[K, V]
+	bitmap  uint32
+}
+
+// hamtEntry is either a subtree if node is non-nil, or a leaf.
+type hamtEntry[K comparable, V any] struct {
+	node  *hamtNode[K, V]
+	key   K
+	value V
+	hash  uint64
+}
+
+func hamtHash[K comparable](key K) uint64 {
+	return maphash.Comparable(hamtSeed, key)
+}
+
+func hamtBit(hash uint64, shift uint) uint32 {
+	return 1 << ((hash >> shift) & hamtMask)
+}
+
+func hamtLeaf[K comparable, V any](key K, value V, hash uint64) hamtEntry[K, V] {
+	return hamtEntry[K, V]{node: nil, key: key, value: value, hash: hash}
+}
+
+func hamtSubtree[K comparable, V any](node *hamtNode[K, V]) hamtEntry[K, V] {
+	var e hamtEntry[K, V]
+	e.node = node
+	return e
+}
+
+func (n *hamtNode[K, V]) index(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// get returns the value associated with key.
+func (t hamt[K, V]) get(key K) (V, bool) {
+	hash := hamtHash(key)
+	n := t.root
+	for shift := uint(0); n != nil; shift += hamtBits {
+		if shift >= hamtHashBits {
+			for _, e := range n.entries {
+				if e.key == key {
+					return e.value, true
+				}
+			}
+			break
+		}
+		bit := hamtBit(hash, shift)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		e := &n.entries[n.index(bit)]
+		if e.node == nil {
+			if e.key == key {
+				return e.value, true
+			}
+			break
+		}
+		n = e.node
+	}
+	var zero V
+	return zero, false
+}
+
+// set returns a trie in which key is associated with value.
+// The second return value reports whether key is newly added.
+func (t hamt[K, V]) set(key K, value V) (hamt[K, V], bool) {
+	root, added := t.root.set(hamtLeaf(key, value, hamtHash(key)), 0)
+	ret := hamt[K, V]{root: root, len: t.len}
+	if added {
+		ret.len++
+	}
+	return ret, added
+}
+
+func (n *hamtNode[K, V]) set(leaf hamtEntry[K, V], shift uint) (*hamtNode[K, V], bool) {
+	if n == nil {
+		if shift >= hamtHashBits {
+			return &hamtNode[K, V]{entries: []hamtEntry[K, V]{leaf}, bitmap: 0}, true
+		}
+		return &hamtNode[K, V]{entries: []hamtEntry[K, V]{leaf}, bitmap: hamtBit(leaf.hash, shift)}, true
+	}
+	if shift >= hamtHashBits {
+		entries := slices.Clone(n.entries)
+		for i, e := range entries {
+			if e.key == leaf.key {
+				entries[i] = leaf
+				return &hamtNode[K, V]{entries: entries, bitmap: 0}, false
+			}
+		}
+		return &hamtNode[K, V]{entries: append(entries, leaf), bitmap: 0}, true
+	}
+	bit := hamtBit(leaf.hash, shift)
+	i := n.index(bit)
+	if n.bitmap&bit == 0 {
+		return &hamtNode[K, V]{entries: slices.Insert(slices.Clone(n.entries), i, leaf), bitmap: n.bitmap | bit}, true
+	}
+	var (
+		replacement hamtEntry[K, V]
+		added       bool
+	)
+	switch e := n.entries[i]; {
+	case e.node != nil:
+		var child *hamtNode[K, V]
+		child, added = e.node.set(leaf, shift+hamtBits)
+		replacement = hamtSubtree(child)
+	case e.key == leaf.key:
+		replacement = leaf
+	default:
+		replacement = hamtSubtree(newHamtPair(e, leaf, shift+hamtBits))
+		added = true
+	}
+	entries := slices.Clone(n.entries)
+	entries[i] = replacement
+	return &hamtNode[K, V]{entries: entries, bitmap: n.bitmap}, added
+}
+
+// newHamtPair returns a subtree at the depth of shift that holds the two leaves.
+func newHamtPair[K comparable, V any](a, b hamtEntry[K, V], shift uint) *hamtNode[K, V] {
+	if shift >= hamtHashBits {
+		return &hamtNode[K, V]{entries: []hamtEntry[K, V]{a, b}, bitmap: 0}
+	}
+	bitA, bitB := hamtBit(a.hash, shift), hamtBit(b.hash, shift)
+	if bitA == bitB {
+		return &hamtNode[K, V]{entries: []hamtEntry[K, V]{hamtSubtree(newHamtPair(a, b, shift+hamtBits))}, bitmap: bitA}
+	}
+	if bitA > bitB {
+		a, b = b, a
+	}
+	return &hamtNode[K, V]{entries: []hamtEntry[K, V]{a, b}, bitmap: bitA | bitB}
+}
+
+// delete returns a trie without key.
+// The second return value reports whether key was found; if not, t itself is returned.
+func (t hamt[K, V]) delete(key K) (hamt[K, V], bool) {
+	root, found := t.root.delete(key, hamtHash(key), 0)
+	if !found {
+		return t, false
+	}
+	return hamt[K, V]{root: root, len: t.len - 1}, true
+}
+
+func (n *hamtNode[K, V]) delete(key K, hash uint64, shift uint) (*hamtNode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	if shift >= hamtHashBits {
+		i := slices.IndexFunc(n.entries, func(e hamtEntry[K, V]) bool { return e.key == key })
+		if i < 0 {
+			return n, false
+		}
+		return n.without(i, 0), true
+	}
+	bit := hamtBit(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	i := n.index(bit)
+	e := n.entries[i]
+	if e.node == nil {
+		if e.key != key {
+			return n, false
+		}
+		return n.without(i, bit), true
+	}
+	child, found := e.node.delete(key, hash, shift+hamtBits)
+	if !found {
+		return n, false
+	}
+	if child == nil {
+		return n.without(i, bit), true
+	}
+	entries := slices.Clone(n.entries)
+	if len(child.entries) == 1 && child.entries[0].node == nil {
+		// pull a lone leaf up so that the trie stays as shallow as if the removed key had never been added
+		entries[i] = child.entries[0]
+	} else {
+		entries[i] = hamtSubtree(child)
+	}
+	return &hamtNode[K, V]{entries: entries, bitmap: n.bitmap}, true
+}
+
+// without returns a copy of n without the i-th entry, whose bit is cleared from the bitmap.
+// It returns nil if no entries would remain.
+func (n *hamtNode[K, V]) without(i int, bit uint32) *hamtNode[K, V] {
+	if len(n.entries) == 1 {
+		return nil
+	}
+	return &hamtNode[K, V]{entries: slices.Delete(slices.Clone(n.entries), i, i+1), bitmap: n.bitmap &^ bit}
+}
+
+// all returns an iterator over the key-value pairs in the order of their hashes.
+func (t hamt[K, V]) all() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.root.all(yield)
+	}
+}
+
+func (n *hamtNode[K, V]) all(yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	for i := range n.entries {
+		e := &n.entries[i]
+		if e.node != nil {
+			if !e.node.all(yield) {
+				return false
+			}
+			continue
+		}
+		if !yield(e.key, e.value) {
+			return false
+		}
+	}
+	return true
+}
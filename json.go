@@ -0,0 +1,298 @@
+package coll
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// MarshalJSON implements [json.Marshaler].
+// The set is encoded as a JSON array.
+func (s *Set[E]) MarshalJSON() ([]byte, error) {
+	return marshalJSONArray[E](s)
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+// It replaces the elements of the set with the ones in the JSON array; duplicates are ignored.
+func (s *Set[E]) UnmarshalJSON(data []byte) error {
+	els, err := unmarshalJSONArray[E](data)
+	if err != nil || els == nil {
+		return err
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.unsync.replace(els)
+	return nil
+}
+
+// MarshalJSON implements [json.Marshaler].
+// The set is encoded as a JSON array in insertion order.
+func (s *OrderedSet[E]) MarshalJSON() ([]byte, error) {
+	return marshalJSONArray[E](s)
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+// It replaces the elements of the set with the ones in the JSON array, keeping their order; duplicates are ignored.
+func (s *OrderedSet[E]) UnmarshalJSON(data []byte) error {
+	els, err := unmarshalJSONArray[E](data)
+	if err != nil || els == nil {
+		return err
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.unsync.replace(els)
+	return nil
+}
+
+// MarshalJSON implements [json.Marshaler].
+// The map is encoded as a JSON object whose members appear in insertion order.
+//
+// Keys are encoded in the same way as [json.Marshal] does for Go maps:
+// string keys are used directly, keys implementing [encoding.TextMarshaler] are marshaled,
+// and integer keys are converted to strings.
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalJSONObject(m.All())
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+// It replaces the entries of the map with the members of the JSON object, keeping the order in which they appear.
+// If a member name appears more than once, the last value wins and the key keeps its first position.
+//
+// Keys are decoded in the same way as [json.Unmarshal] does for Go maps.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	keys, values, err := unmarshalJSONObject[K, V](data, reflect.TypeOf(m))
+	if err != nil {
+		return err
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.unsync.replace(keys, values)
+	return nil
+}
+
+// MarshalJSON implements [json.Marshaler] in the same format as [Set.MarshalJSON].
+func (s *UnsyncSet[E]) MarshalJSON() ([]byte, error) {
+	return marshalJSONArray[E](s)
+}
+
+// UnmarshalJSON implements [json.Unmarshaler] in the same way as [Set.UnmarshalJSON].
+func (s *UnsyncSet[E]) UnmarshalJSON(data []byte) error {
+	els, err := unmarshalJSONArray[E](data)
+	if err != nil || els == nil {
+		return err
+	}
+	s.replace(els)
+	return nil
+}
+
+// MarshalJSON implements [json.Marshaler] in the same format as [OrderedSet.MarshalJSON].
+func (s *UnsyncOrderedSet[E]) MarshalJSON() ([]byte, error) {
+	return marshalJSONArray[E](s)
+}
+
+// UnmarshalJSON implements [json.Unmarshaler] in the same way as [OrderedSet.UnmarshalJSON].
+func (s *UnsyncOrderedSet[E]) UnmarshalJSON(data []byte) error {
+	els, err := unmarshalJSONArray[E](data)
+	if err != nil || els == nil {
+		return err
+	}
+	s.replace(els)
+	return nil
+}
+
+// MarshalJSON implements [json.Marshaler] in the same format as [OrderedMap.MarshalJSON].
+func (m *UnsyncOrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalJSONObject(m.All())
+}
+
+// UnmarshalJSON implements [json.Unmarshaler] in the same way as [OrderedMap.UnmarshalJSON].
+func (m *UnsyncOrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	keys, values, err := unmarshalJSONObject[K, V](data, reflect.TypeOf(m))
+	if err != nil {
+		return err
+	}
+	m.replace(keys, values)
+	return nil
+}
+
+func marshalJSONObject[K comparable, V any](all iter.Seq2[K, V]) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteByte('{')
+	first := true
+	for key, value := range all {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		name, err := marshalMapKey(key)
+		if err != nil {
+			return nil, err
+		}
+		encodedName, err := marshalJSON(name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedName)
+		buf.WriteByte(':')
+		encodedValue, err := marshalJSON(value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedValue)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// unmarshalJSONObject decodes the members of the JSON object in data in the order in which they appear.
+// typ is the type reported when data is not an object.
+func unmarshalJSONObject[K comparable, V any](data []byte, typ reflect.Type) ([]K, []V, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if tok != json.Delim('{') {
+		return nil, nil, &json.UnmarshalTypeError{Value: describeJSONToken(tok), Type: typ, Offset: dec.InputOffset()}
+	}
+	var (
+		keys   []K
+		values []V
+	)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		name, _ := tok.(string) // object member names are always strings
+		key, err := unmarshalMapKey[K](name)
+		if err != nil {
+			return nil, nil, &json.UnmarshalTypeError{Value: "string " + strconv.Quote(name), Type: reflect.TypeFor[K](), Offset: dec.InputOffset()}
+		}
+		var value V
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+	return keys, values, nil
+}
+
+func marshalJSONArray[E comparable](s SetLike[E]) ([]byte, error) {
+	els := slices.Collect(s.Values())
+	if els == nil {
+		els = []E{}
+	}
+	return marshalJSON(els)
+}
+
+func unmarshalJSONArray[E comparable](data []byte) ([]E, error) {
+	if isJSONNull(data) {
+		return nil, nil
+	}
+	els := []E{}
+	if err := json.Unmarshal(data, &els); err != nil {
+		return nil, err
+	}
+	return els, nil
+}
+
+// marshalJSON encodes v without escaping HTML characters.
+// The escaping is left to the outer encoder, which applies it when it compacts the output of a [json.Marshaler].
+func marshalJSON(v any) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func isJSONNull(data []byte) bool {
+	return string(bytes.TrimSpace(data)) == "null"
+}
+
+func describeJSONToken(tok json.Token) string {
+	switch tok.(type) {
+	case json.Delim:
+		if tok == json.Delim('[') {
+			return "array"
+		}
+		return "object"
+	case string:
+		return "string"
+	case float64, json.Number:
+		return "number"
+	case bool:
+		return "bool"
+	default:
+		return "null"
+	}
+}
+
+// marshalMapKey converts the key into a JSON object member name following the rules of [json.Marshal].
+func marshalMapKey[K comparable](key K) (string, error) {
+	rv := reflect.ValueOf(&key).Elem()
+	if rv.Kind() == reflect.String {
+		return rv.String(), nil
+	}
+	if tm, ok := any(key).(encoding.TextMarshaler); ok {
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return "", nil
+		}
+		text, err := tm.MarshalText()
+		return string(text), err
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	default:
+		return "", &json.UnsupportedTypeError{Type: rv.Type()}
+	}
+}
+
+// unmarshalMapKey converts the JSON object member name into a key following the rules of [json.Unmarshal].
+func unmarshalMapKey[K comparable](name string) (K, error) {
+	var key K
+	if tu, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err := tu.UnmarshalText([]byte(name))
+		return key, err
+	}
+	rv := reflect.ValueOf(&key).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(name)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(name, 10, rv.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(name, 10, rv.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		rv.SetUint(n)
+	default:
+		return key, &json.UnsupportedTypeError{Type: rv.Type()}
+	}
+	return key, nil
+}
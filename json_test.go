@@ -0,0 +1,308 @@
+package coll_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func TestSet_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		set  *coll.Set[string]
+		name string
+		want string
+	}{
+		{
+			name: "empty",
+			set:  coll.NewSet[string](),
+			want: `[]`,
+		},
+		{
+			name: "zero value",
+			set:  new(coll.Set[string]),
+			want: `[]`,
+		},
+		{
+			name: "escapes HTML like json.Marshal",
+			set:  coll.NewSet("<a>"),
+			want: `["\u003ca\u003e"]`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := json.Marshal(tc.set)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("mismatch:\n\twant: %s\n\t got: %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSet_UnmarshalJSON(t *testing.T) {
+	var s coll.Set[int]
+	if err := json.Unmarshal([]byte(`[3, 1, 2, 1]`), &s); err != nil {
+		t.Fatal(err)
+	}
+	got := slices.Sorted(s.Values())
+	want := []int{1, 2, 3}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if err := json.Unmarshal([]byte(`[4]`), &s); err != nil {
+		t.Fatal(err)
+	}
+	got = slices.Sorted(s.Values())
+	want = []int{4}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if err := json.Unmarshal([]byte(`{}`), &s); err == nil {
+		t.Error("expected an error for a JSON object")
+	}
+}
+
+func TestOrderedSet_JSON(t *testing.T) {
+	type response struct {
+		Tags *coll.OrderedSet[string] `json:"tags"`
+	}
+	input := response{Tags: coll.NewOrderedSet("c", "a", "b")}
+	encoded, err := json.Marshal(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"tags":["c","a","b"]}`; string(encoded) != want {
+		t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, encoded)
+	}
+
+	var decoded response
+	if err := json.Unmarshal([]byte(`{"tags":["z","x","z","y"]}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	got := slices.Collect(decoded.Tags.Values())
+	want := []string{"z", "x", "y"}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"tags":null}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Tags != nil {
+		t.Errorf("expected nil set, got %v", slices.Collect(decoded.Tags.Values()))
+	}
+}
+
+func TestOrderedMap_MarshalJSON(t *testing.T) {
+	t.Run("string keys", func(t *testing.T) {
+		m := coll.NewOrderedMap[string, any]()
+		m.Put("z", 1)
+		m.Put("a", []string{"x"})
+		m.Put("m", map[string]int{"b": 2})
+		got, err := json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"z":1,"a":["x"],"m":{"b":2}}`; string(got) != want {
+			t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		got, err := json.Marshal(coll.NewOrderedMap[string, int]())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{}`; string(got) != want {
+			t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+		}
+	})
+	t.Run("integer keys", func(t *testing.T) {
+		m := coll.NewOrderedMap[int, string]()
+		m.Put(10, "ten")
+		m.Put(-1, "minus one")
+		got, err := json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"10":"ten","-1":"minus one"}`; string(got) != want {
+			t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+		}
+	})
+	t.Run("text marshaler keys", func(t *testing.T) {
+		m := coll.NewOrderedMap[netip.Addr, bool]()
+		m.Put(netip.MustParseAddr("192.0.2.1"), true)
+		m.Put(netip.MustParseAddr("2001:db8::1"), false)
+		got, err := json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"192.0.2.1":true,"2001:db8::1":false}`; string(got) != want {
+			t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+		}
+	})
+	t.Run("nested", func(t *testing.T) {
+		inner := coll.NewOrderedMap[string, *coll.OrderedSet[int]]()
+		inner.Put("b", coll.NewOrderedSet(2, 1))
+		inner.Put("a", coll.NewOrderedSet(3))
+		outer := coll.NewOrderedMap[string, *coll.OrderedMap[string, *coll.OrderedSet[int]]]()
+		outer.Put("inner", inner)
+		got, err := json.Marshal(outer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"inner":{"b":[2,1],"a":[3]}}`; string(got) != want {
+			t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+		}
+	})
+	t.Run("unsupported keys", func(t *testing.T) {
+		type point struct{ x, y int }
+		m := coll.NewOrderedMap[point, int]()
+		m.Put(point{1, 2}, 3)
+		_, err := json.Marshal(m)
+		if ute := new(json.UnsupportedTypeError); !errors.As(err, &ute) {
+			t.Errorf("expected UnsupportedTypeError, got %v", err)
+		}
+	})
+}
+
+func TestOrderedMap_UnmarshalJSON(t *testing.T) {
+	t.Run("string keys", func(t *testing.T) {
+		var m coll.OrderedMap[string, int]
+		if err := json.Unmarshal([]byte(`{"z": 1, "a": 2, "m": 3, "a": 4}`), &m); err != nil {
+			t.Fatal(err)
+		}
+		gotKeys := slices.Collect(m.Keys())
+		wantKeys := []string{"z", "a", "m"}
+		if !reflect.DeepEqual(wantKeys, gotKeys) {
+			t.Errorf("Keys: got %v, want %v", gotKeys, wantKeys)
+		}
+		gotVals := slices.Collect(m.Values())
+		wantVals := []int{1, 4, 3}
+		if !reflect.DeepEqual(wantVals, gotVals) {
+			t.Errorf("Values: got %v, want %v", gotVals, wantVals)
+		}
+	})
+	t.Run("replaces entries", func(t *testing.T) {
+		m := coll.NewOrderedMap[string, int]()
+		m.Put("old", 1)
+		if err := json.Unmarshal([]byte(`{"new": 2}`), m); err != nil {
+			t.Fatal(err)
+		}
+		gotKeys := slices.Collect(m.Keys())
+		wantKeys := []string{"new"}
+		if !reflect.DeepEqual(wantKeys, gotKeys) {
+			t.Errorf("Keys: got %v, want %v", gotKeys, wantKeys)
+		}
+	})
+	t.Run("integer keys", func(t *testing.T) {
+		var m coll.OrderedMap[int8, string]
+		if err := json.Unmarshal([]byte(`{"3": "c", "-1": "a"}`), &m); err != nil {
+			t.Fatal(err)
+		}
+		gotKeys := slices.Collect(m.Keys())
+		wantKeys := []int8{3, -1}
+		if !reflect.DeepEqual(wantKeys, gotKeys) {
+			t.Errorf("Keys: got %v, want %v", gotKeys, wantKeys)
+		}
+		if err := json.Unmarshal([]byte(`{"300": "overflow"}`), &m); err == nil {
+			t.Error("expected an error for an overflowing key")
+		}
+	})
+	t.Run("text unmarshaler keys", func(t *testing.T) {
+		var m coll.OrderedMap[netip.Addr, int]
+		if err := json.Unmarshal([]byte(`{"2001:db8::1": 1, "192.0.2.1": 2}`), &m); err != nil {
+			t.Fatal(err)
+		}
+		gotKeys := slices.Collect(m.Keys())
+		wantKeys := []netip.Addr{netip.MustParseAddr("2001:db8::1"), netip.MustParseAddr("192.0.2.1")}
+		if !reflect.DeepEqual(wantKeys, gotKeys) {
+			t.Errorf("Keys: got %v, want %v", gotKeys, wantKeys)
+		}
+	})
+	t.Run("nested", func(t *testing.T) {
+		var m coll.OrderedMap[string, *coll.OrderedMap[string, int]]
+		if err := json.Unmarshal([]byte(`{"y": {"b": 1, "a": 2}, "x": {}}`), &m); err != nil {
+			t.Fatal(err)
+		}
+		gotKeys := slices.Collect(m.Keys())
+		wantKeys := []string{"y", "x"}
+		if !reflect.DeepEqual(wantKeys, gotKeys) {
+			t.Errorf("Keys: got %v, want %v", gotKeys, wantKeys)
+		}
+		inner, _ := m.Get("y")
+		gotInnerKeys := slices.Collect(inner.Keys())
+		wantInnerKeys := []string{"b", "a"}
+		if !reflect.DeepEqual(wantInnerKeys, gotInnerKeys) {
+			t.Errorf("Keys: got %v, want %v", gotInnerKeys, wantInnerKeys)
+		}
+	})
+	t.Run("not an object", func(t *testing.T) {
+		var m coll.OrderedMap[string, int]
+		err := json.Unmarshal([]byte(`[1, 2]`), &m)
+		if ute := new(json.UnmarshalTypeError); !errors.As(err, &ute) {
+			t.Errorf("expected UnmarshalTypeError, got %v", err)
+		}
+	})
+	t.Run("round trip", func(t *testing.T) {
+		input := `{"c":[1,2],"a":{"nested":true},"b":"<html>"}`
+		var m coll.OrderedMap[string, json.RawMessage]
+		if err := json.Unmarshal([]byte(input), &m); err != nil {
+			t.Fatal(err)
+		}
+		buf := new(bytes.Buffer)
+		enc := json.NewEncoder(buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(&m); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := buf.String(), "{\"c\":[1,2],\"a\":{\"nested\":true},\"b\":\"<html>\"}\n"; got != want {
+			t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+		}
+	})
+}
+
+func TestUnsync_JSON(t *testing.T) {
+	t.Run("UnsyncSet", func(t *testing.T) {
+		var s coll.UnsyncSet[int]
+		if err := json.Unmarshal([]byte(`[3, 1, 2, 1]`), &s); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := slices.Sorted(s.Values()), []int{1, 2, 3}; !reflect.DeepEqual(want, got) {
+			t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+		}
+	})
+	t.Run("UnsyncOrderedSet", func(t *testing.T) {
+		var s coll.UnsyncOrderedSet[string]
+		if err := json.Unmarshal([]byte(`["c", "a", "b"]`), &s); err != nil {
+			t.Fatal(err)
+		}
+		got, err := json.Marshal(&s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `["c","a","b"]`; string(got) != want {
+			t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+		}
+	})
+	t.Run("UnsyncOrderedMap", func(t *testing.T) {
+		var m coll.UnsyncOrderedMap[string, int]
+		if err := json.Unmarshal([]byte(`{"z": 1, "a": 2}`), &m); err != nil {
+			t.Fatal(err)
+		}
+		got, err := json.Marshal(&m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"z":1,"a":2}`; string(got) != want {
+			t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package coll
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+var errTrailingJSONData = errors.New("invalid data after top-level JSON value")
+
+// DecodeJSONTree reads the next JSON value from dec and returns it as a tree that keeps the order of object members.
+//
+// JSON objects become *OrderedMap[string, any] whose keys are in document order;
+// if a member name appears more than once, the last value wins and the key keeps its first position.
+// JSON arrays become []any, and scalars become the values returned by [json.Decoder.Token]:
+// string, bool, nil, and float64 or [json.Number] if [json.Decoder.UseNumber] was called.
+//
+// The value is read token by token, so dec may be positioned in the middle of a stream.
+// It returns [io.EOF] if there are no more values in the stream.
+func DecodeJSONTree(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSONTreeValue(dec, tok)
+}
+
+func decodeJSONTreeValue(dec *json.Decoder, tok json.Token) (any, error) {
+	switch tok {
+	case json.Delim('{'):
+		obj := NewOrderedMap[string, any]()
+		for dec.More() {
+			nameTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			name, _ := nameTok.(string) // object member names are always strings
+			value, err := DecodeJSONTree(dec)
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			obj.Set(name, value)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		return obj, nil
+	case json.Delim('['):
+		arr := []any{}
+		for dec.More() {
+			value, err := DecodeJSONTree(dec)
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			arr = append(arr, value)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		return arr, nil
+	default:
+		return tok, nil
+	}
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// UnmarshalJSONTree parses the JSON document in data into a tree as [DecodeJSONTree] does.
+// Numbers are decoded as [json.Number] so that their original text is kept.
+func UnmarshalJSONTree(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	v, err := DecodeJSONTree(dec)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return nil, err
+		}
+		return nil, errTrailingJSONData
+	}
+	return v, nil
+}
+
+// MarshalJSONTree encodes the tree built by [UnmarshalJSONTree] or [DecodeJSONTree] back into compact JSON.
+//
+// Object members are written in the order of the *OrderedMap and strings are not HTML-escaped,
+// so a compact document without escape sequences is reproduced byte for byte.
+// Use [json.Indent] to format the result.
+func MarshalJSONTree(v any) ([]byte, error) {
+	return marshalJSON(v)
+}
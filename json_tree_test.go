@@ -0,0 +1,137 @@
+package coll_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func TestUnmarshalJSONTree_roundTrip(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{name: "object", input: `{"z":1,"a":{"y":[true,false,null],"b":"<tag> & text"},"m":[]}`},
+		{name: "array", input: `[{"b":1,"a":2},1.50,-0,1e+100,12345678901234567890]`},
+		{name: "scalar", input: `"string"`},
+		{name: "empty object", input: `{}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tree, err := coll.UnmarshalJSONTree([]byte(tc.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := coll.MarshalJSONTree(tree)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tc.input {
+				t.Errorf("mismatch:\n\twant: %s\n\t got: %s", tc.input, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONTree_edit(t *testing.T) {
+	tree, err := coll.UnmarshalJSONTree([]byte(`{
+  "name": "app",
+  "version": "1.0.0",
+  "dependencies": {"zlib": "1.2", "abc": "0.1"}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, ok := tree.(*coll.OrderedMap[string, any])
+	if !ok {
+		t.Fatalf("root is not an OrderedMap: %T", tree)
+	}
+	root.Set("version", "1.1.0")
+	deps, _ := root.Get("dependencies")
+	deps.(*coll.OrderedMap[string, any]).Set("new", "2.0")
+
+	got, err := coll.MarshalJSONTree(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"app","version":"1.1.0","dependencies":{"zlib":"1.2","abc":"0.1","new":"2.0"}}`
+	if string(got) != want {
+		t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+	}
+}
+
+func TestUnmarshalJSONTree_duplicateNames(t *testing.T) {
+	tree, err := coll.UnmarshalJSONTree([]byte(`{"a":1,"b":2,"a":3}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := coll.MarshalJSONTree(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"a":3,"b":2}`; string(got) != want {
+		t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+	}
+}
+
+func TestUnmarshalJSONTree_errors(t *testing.T) {
+	testCases := []struct {
+		wantErr error
+		name    string
+		input   string
+	}{
+		{name: "empty", input: ``, wantErr: io.ErrUnexpectedEOF},
+		{name: "unterminated object", input: `{"a":1`, wantErr: nil},
+		{name: "unterminated array", input: `[1,`, wantErr: nil},
+		{name: "trailing data", input: `{} []`, wantErr: nil},
+		{name: "invalid", input: `{"a" 1}`, wantErr: nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := coll.UnmarshalJSONTree([]byte(tc.input))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDecodeJSONTree_stream(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`{"b":1,"a":2} [3] "x"`))
+	var got []any
+	for {
+		v, err := coll.DecodeJSONTree(dec)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 values, got %d", len(got))
+	}
+	obj, ok := got[0].(*coll.OrderedMap[string, any])
+	if !ok {
+		t.Fatalf("first value is not an OrderedMap: %T", got[0])
+	}
+	if gotKeys, wantKeys := slices.Collect(obj.Keys()), []string{"b", "a"}; !reflect.DeepEqual(wantKeys, gotKeys) {
+		t.Errorf("Keys: got %v, want %v", gotKeys, wantKeys)
+	}
+	if want := []any{float64(3)}; !reflect.DeepEqual(want, got[1]) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got[1])
+	}
+	if want := "x"; got[2] != want {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got[2])
+	}
+}
@@ -0,0 +1,79 @@
+package coll
+
+import "iter"
+
+// listElement is an element of linkedList.
+type listElement[T any] struct {
+	prev  *listElement[T]
+	next  *listElement[T]
+	value T
+}
+
+// linkedList is a doubly linked list that allows O(1) removal of any element.
+// The zero value is an empty list ready to use.
+// Since the sentinel is allocated separately, the list can be moved to another owner by copying the struct.
+//
+// It is not safe for concurrent use; the owner must guard it.
+type linkedList[T any] struct {
+	root *listElement[T] // sentinel: root.next is the front and root.prev is the back
+	len  int
+}
+
+func (l *linkedList[T]) lazyInit() {
+	if l.root == nil {
+		l.root = &listElement[T]{}
+		l.root.next = l.root
+		l.root.prev = l.root
+	}
+}
+
+func (l *linkedList[T]) front() *listElement[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// nextOf returns the element following e, or nil if e is the last one.
+func (l *linkedList[T]) nextOf(e *listElement[T]) *listElement[T] {
+	if e.next == l.root {
+		return nil
+	}
+	return e.next
+}
+
+func (l *linkedList[T]) pushBack(v T) *listElement[T] {
+	l.lazyInit()
+	e := &listElement[T]{prev: l.root.prev, next: l.root, value: v}
+	e.prev.next = e
+	l.root.prev = e
+	l.len++
+	return e
+}
+
+func (l *linkedList[T]) remove(e *listElement[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev = nil
+	e.next = nil
+	l.len--
+}
+
+func (l *linkedList[T]) clear() {
+	l.root = nil
+	l.len = 0
+}
+
+// all returns an iterator over the elements from front to back.
+// The element being yielded may be removed from within the loop body.
+func (l *linkedList[T]) all() iter.Seq[*listElement[T]] {
+	return func(yield func(*listElement[T]) bool) {
+		for e := l.front(); e != nil; {
+			next := l.nextOf(e)
+			if !yield(e) {
+				return
+			}
+			e = next
+		}
+	}
+}
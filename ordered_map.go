@@ -2,31 +2,34 @@ package coll
 
 import (
 	"iter"
-	"slices"
 	"sync"
 )
 
 // NewOrderedMap returns a new instance of OrderedMap.
 func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
 	om := &OrderedMap[K, V]{
-		keys:  make([]K, 0),
-		dirty: map[K]V{},
-		mux:   sync.RWMutex{},
+		unsync: *NewUnsyncOrderedMap[K, V](),
+		mux:    sync.RWMutex{},
 	}
 	return om
 }
 
 // OrderedMap represents a map that preserves insertion order of keys.
 // It is safe for concurrent use.
+//
+// Iterators returned by the map hold the read lock for the duration of the iteration,
+// so the map must not be modified from within the loop body.
 type OrderedMap[K comparable, V any] struct {
-	dirty map[K]V
-	keys  []K
-	mux   sync.RWMutex
+	unsync UnsyncOrderedMap[K, V]
+	mux    sync.RWMutex
 }
 
-func (m *OrderedMap[K, V]) unsafeGet(key K) (V, bool) {
-	val, ok := m.dirty[key]
-	return val, ok
+// Len returns the number of entries in the map.
+// It is safe for concurrent use.
+func (m *OrderedMap[K, V]) Len() int {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return m.unsync.Len()
 }
 
 // Get retrieves the value associated with the given key.
@@ -35,12 +38,7 @@ func (m *OrderedMap[K, V]) unsafeGet(key K) (V, bool) {
 func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
 	m.mux.RLock()
 	defer m.mux.RUnlock()
-	return m.unsafeGet(key)
-}
-
-func (m *OrderedMap[K, V]) unsafePut(key K, value V) {
-	m.dirty[key] = value
-	m.keys = append(m.keys, key)
+	return m.unsync.Get(key)
 }
 
 // Put inserts the key-value pair into the map if the key does not already exist.
@@ -48,23 +46,70 @@ func (m *OrderedMap[K, V]) unsafePut(key K, value V) {
 func (m *OrderedMap[K, V]) Put(key K, value V) {
 	m.mux.Lock()
 	defer m.mux.Unlock()
-	if _, found := m.dirty[key]; found {
-		return
-	}
-	m.unsafePut(key, value)
+	m.unsync.Put(key, value)
+}
+
+// Set associates the value with the key.
+// If the key already exists, its value is overwritten and the key keeps its position;
+// otherwise the key is appended. It is safe for concurrent use.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.unsync.Set(key, value)
 }
 
 // Update updates the value associated with the key using the provided function.
 // The updater function receives the current value (or zero value if not found) and a boolean indicating existence.
+// If the key already exists, it keeps its position; otherwise the key is appended.
 // It is safe for concurrent use.
 func (m *OrderedMap[K, V]) Update(key K, update func(prev V, alreadyExist bool) V) {
 	m.mux.Lock()
 	defer m.mux.Unlock()
-	m.unsafePut(key, update(m.unsafeGet(key)))
+	m.unsync.Update(key, update)
+}
+
+// Delete removes the key from the map and returns the value that was associated with it.
+// The second return value indicates whether the key was found.
+// The order of the remaining keys is preserved. It runs in O(1) and is safe for concurrent use.
+func (m *OrderedMap[K, V]) Delete(key K) (V, bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return m.unsync.Delete(key)
 }
 
-func (m *OrderedMap[K, V]) unsafeKeysIterator() iter.Seq[K] {
-	return slices.Values(m.keys)
+// DeleteFunc removes every entry for which del returns true.
+// The order of the remaining keys is preserved. It is safe for concurrent use.
+func (m *OrderedMap[K, V]) DeleteFunc(del func(key K, value V) bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.unsync.DeleteFunc(del)
+}
+
+// Clear removes all entries from the map.
+// It is safe for concurrent use.
+func (m *OrderedMap[K, V]) Clear() {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.unsync.Clear()
+}
+
+// Unsync returns a new [UnsyncOrderedMap] containing a copy of the entries of m, keeping their order.
+// m is left unchanged; use [OrderedMap.MoveToUnsync] to convert without copying. It is safe for concurrent use.
+func (m *OrderedMap[K, V]) Unsync() *UnsyncOrderedMap[K, V] {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return m.unsync.clone()
+}
+
+// MoveToUnsync moves the entries of m into a new [UnsyncOrderedMap] and leaves m empty.
+// The entries are not copied, so the conversion runs in O(1).
+// It is safe for concurrent use, but other goroutines that still use m observe it as empty afterwards.
+func (m *OrderedMap[K, V]) MoveToUnsync() *UnsyncOrderedMap[K, V] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	ret := &UnsyncOrderedMap[K, V]{dirty: m.unsync.dirty, entries: m.unsync.entries}
+	m.unsync = UnsyncOrderedMap[K, V]{dirty: nil, entries: linkedList[orderedMapEntry[K, V]]{}}
+	return ret
 }
 
 // Keys returns an iterator over the keys in insertion order.
@@ -73,7 +118,7 @@ func (m *OrderedMap[K, V]) Keys() iter.Seq[K] {
 	return func(yield func(K) bool) {
 		m.mux.RLock()
 		defer m.mux.RUnlock()
-		m.unsafeKeysIterator()(yield)
+		m.unsync.Keys()(yield)
 	}
 }
 
@@ -83,11 +128,7 @@ func (m *OrderedMap[K, V]) Values() iter.Seq[V] {
 	return func(yield func(V) bool) {
 		m.mux.RLock()
 		defer m.mux.RUnlock()
-		for key := range m.unsafeKeysIterator() {
-			if !yield(m.dirty[key]) {
-				return
-			}
-		}
+		m.unsync.Values()(yield)
 	}
 }
 
@@ -97,11 +138,6 @@ func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
 	return func(yield func(K, V) bool) {
 		m.mux.RLock()
 		defer m.mux.RUnlock()
-		for key := range m.unsafeKeysIterator() {
-			val := m.dirty[key]
-			if !yield(key, val) {
-				return
-			}
-		}
+		m.unsync.All()(yield)
 	}
 }
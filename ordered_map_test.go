@@ -2,6 +2,8 @@ package coll_test
 
 import (
 	"reflect"
+	"slices"
+	"strconv"
 	"testing"
 
 	"github.com/aereal/coll"
@@ -140,3 +142,190 @@ func TestOrderedMap_Update(t *testing.T) {
 		t.Errorf("Update new key: got %v, want 42", got)
 	}
 }
+
+func TestOrderedMap_Update_keepsPosition(t *testing.T) {
+	m := coll.NewOrderedMap[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	for range 2 {
+		m.Update("a", func(prev int, _ bool) int { return prev + 1 })
+	}
+
+	gotKeys := slices.Collect(m.Keys())
+	wantKeys := []string{"a", "b"}
+	if !reflect.DeepEqual(gotKeys, wantKeys) {
+		t.Errorf("Keys: got %v, want %v", gotKeys, wantKeys)
+	}
+	gotVals := slices.Collect(m.Values())
+	wantVals := []int{3, 2}
+	if !reflect.DeepEqual(gotVals, wantVals) {
+		t.Errorf("Values: got %v, want %v", gotVals, wantVals)
+	}
+}
+
+func TestOrderedMap_Set(t *testing.T) {
+	m := coll.NewOrderedMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("a", 10)
+	m.Set("c", 3)
+
+	gotKeys := slices.Collect(m.Keys())
+	wantKeys := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(gotKeys, wantKeys) {
+		t.Errorf("Keys: got %v, want %v", gotKeys, wantKeys)
+	}
+	gotVals := slices.Collect(m.Values())
+	wantVals := []int{10, 2, 3}
+	if !reflect.DeepEqual(gotVals, wantVals) {
+		t.Errorf("Values: got %v, want %v", gotVals, wantVals)
+	}
+}
+
+func TestOrderedMap_Delete(t *testing.T) {
+	tests := []struct {
+		name      string
+		deleteKey string
+		wantKeys  []string
+		wantVal   int
+		wantFound bool
+	}{
+		{
+			name:      "first key",
+			deleteKey: "a",
+			wantVal:   1,
+			wantFound: true,
+			wantKeys:  []string{"b", "c"},
+		},
+		{
+			name:      "middle key",
+			deleteKey: "b",
+			wantVal:   2,
+			wantFound: true,
+			wantKeys:  []string{"a", "c"},
+		},
+		{
+			name:      "last key",
+			deleteKey: "c",
+			wantVal:   3,
+			wantFound: true,
+			wantKeys:  []string{"a", "b"},
+		},
+		{
+			name:      "non-existing key",
+			deleteKey: "z",
+			wantVal:   0,
+			wantFound: false,
+			wantKeys:  []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := coll.NewOrderedMap[string, int]()
+			m.Put("a", 1)
+			m.Put("b", 2)
+			m.Put("c", 3)
+			got, ok := m.Delete(tt.deleteKey)
+			if ok != tt.wantFound {
+				t.Errorf("Delete(%q): found = %v, want %v", tt.deleteKey, ok, tt.wantFound)
+			}
+			if got != tt.wantVal {
+				t.Errorf("Delete(%q): got = %v, want %v", tt.deleteKey, got, tt.wantVal)
+			}
+			if _, found := m.Get(tt.deleteKey); found {
+				t.Errorf("Get(%q) finds the deleted key", tt.deleteKey)
+			}
+			if gotKeys := slices.Collect(m.Keys()); !reflect.DeepEqual(gotKeys, tt.wantKeys) {
+				t.Errorf("Keys: got %v, want %v", gotKeys, tt.wantKeys)
+			}
+			if gotLen := m.Len(); gotLen != len(tt.wantKeys) {
+				t.Errorf("Len: got %d, want %d", gotLen, len(tt.wantKeys))
+			}
+		})
+	}
+}
+
+func TestOrderedMap_Delete_thenPut(t *testing.T) {
+	m := coll.NewOrderedMap[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Delete("a")
+	m.Put("a", 10)
+
+	gotKeys := slices.Collect(m.Keys())
+	wantKeys := []string{"b", "a"}
+	if !reflect.DeepEqual(gotKeys, wantKeys) {
+		t.Errorf("Keys: got %v, want %v", gotKeys, wantKeys)
+	}
+	if got, _ := m.Get("a"); got != 10 {
+		t.Errorf("Get(a): got %v, want 10", got)
+	}
+}
+
+func TestOrderedMap_DeleteFunc(t *testing.T) {
+	m := coll.NewOrderedMap[string, int]()
+	for i, k := range []string{"a", "b", "c", "d", "e"} {
+		m.Put(k, i)
+	}
+	m.DeleteFunc(func(_ string, v int) bool { return v%2 == 0 })
+
+	gotKeys := slices.Collect(m.Keys())
+	wantKeys := []string{"b", "d"}
+	if !reflect.DeepEqual(gotKeys, wantKeys) {
+		t.Errorf("Keys: got %v, want %v", gotKeys, wantKeys)
+	}
+	gotVals := slices.Collect(m.Values())
+	wantVals := []int{1, 3}
+	if !reflect.DeepEqual(gotVals, wantVals) {
+		t.Errorf("Values: got %v, want %v", gotVals, wantVals)
+	}
+}
+
+func TestOrderedMap_Clear(t *testing.T) {
+	m := coll.NewOrderedMap[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Clear()
+	if gotLen := m.Len(); gotLen != 0 {
+		t.Errorf("Len: got %d, want 0", gotLen)
+	}
+	if gotKeys := slices.Collect(m.Keys()); len(gotKeys) != 0 {
+		t.Errorf("Keys: got %v, want empty", gotKeys)
+	}
+	m.Put("c", 3)
+	if gotKeys, wantKeys := slices.Collect(m.Keys()), []string{"c"}; !reflect.DeepEqual(gotKeys, wantKeys) {
+		t.Errorf("Keys: got %v, want %v", gotKeys, wantKeys)
+	}
+}
+
+func TestOrderedMap_empty(t *testing.T) {
+	m := new(coll.OrderedMap[string, int])
+	if _, found := m.Get("a"); found {
+		t.Error("Get(a) reports found unexpectedly")
+	}
+	if _, found := m.Delete("a"); found {
+		t.Error("Delete(a) reports found unexpectedly")
+	}
+	m.Put("a", 1)
+	if got, _ := m.Get("a"); got != 1 {
+		t.Errorf("Get(a): got %v, want 1", got)
+	}
+}
+
+func BenchmarkOrderedMap_Delete(b *testing.B) {
+	for _, size := range []int{1_000, 100_000, 1_000_000} {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			m := coll.NewOrderedMap[int, int]()
+			for i := range size {
+				m.Put(i, i)
+			}
+			b.ResetTimer()
+			for i := range b.N {
+				key := (i * 7919) % size
+				m.Delete(key)
+				m.Put(key, key)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package coll
 
 import (
 	"iter"
-	"slices"
 	"sync"
 )
 
@@ -10,41 +9,38 @@ import (
 // Duplicates in the input are ignored, and insertion order is preserved.
 func NewOrderedSet[E comparable](els ...E) *OrderedSet[E] {
 	s := &OrderedSet[E]{
-		existence: map[E]struct{}{},
-		mux:       sync.RWMutex{},
-		values:    []E{},
-	}
-	for _, v := range els {
-		s.unsafeAppend(v)
+		unsync: *NewUnsyncOrderedSet(els...),
+		mux:    sync.RWMutex{},
 	}
 	return s
 }
 
 // OrderedSet represents a set of comparable elements that maintains insertion order.
 // It is safe for concurrent use.
+//
+// Iterators returned by the set hold the read lock for the duration of the iteration,
+// so the set must not be modified from within the loop body.
+// The in-place operations such as [OrderedSet.UnionWith] read their argument before taking the write lock,
+// copying its elements unless it is lock-free like an [UnsyncOrderedSet] or a persistent set.
 type OrderedSet[E comparable] struct {
-	existence map[E]struct{}
-	values    []E
-	mux       sync.RWMutex
+	unsync UnsyncOrderedSet[E]
+	mux    sync.RWMutex
 }
 
 // Len returns the number of elements in the set.
-func (s *OrderedSet[E]) Len() int { return len(s.values) }
+// It is safe for concurrent use.
+func (s *OrderedSet[E]) Len() int {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return s.unsync.Len()
+}
 
 // Contains reports whether the element is present in the set.
 // It is safe for concurrent use.
 func (s *OrderedSet[E]) Contains(el E) bool {
 	s.mux.RLock()
 	defer s.mux.RUnlock()
-	return s.unsafeContains(el)
-}
-
-func (s *OrderedSet[E]) unsafeContains(el E) bool {
-	if s.existence == nil {
-		s.existence = map[E]struct{}{}
-	}
-	_, found := s.existence[el]
-	return found
+	return s.unsync.Contains(el)
 }
 
 // Append adds the element to the set if it does not already exist.
@@ -52,43 +48,74 @@ func (s *OrderedSet[E]) unsafeContains(el E) bool {
 func (s *OrderedSet[E]) Append(el E) {
 	s.mux.Lock()
 	defer s.mux.Unlock()
-	s.unsafeAppend(el)
-}
-
-func (s *OrderedSet[E]) unsafeAppend(el E) {
-	if s.unsafeContains(el) {
-		return
-	}
-	s.existence[el] = struct{}{}
-	s.values = append(s.values, el)
+	s.unsync.Append(el)
 }
 
 // Values returns an iterator over the elements of the set in insertion order.
+// It is safe for concurrent use.
 func (s *OrderedSet[E]) Values() iter.Seq[E] {
 	return func(yield func(E) bool) {
-		for _, el := range s.values {
-			if !yield(el) {
-				return
-			}
-		}
+		s.mux.RLock()
+		defer s.mux.RUnlock()
+		s.unsync.Values()(yield)
 	}
 }
 
+// Remove removes the element from the set if it exists.
+// The order of the remaining elements is preserved. It runs in O(1) and is safe for concurrent use.
 func (s *OrderedSet[E]) Remove(removedEl E) {
 	s.mux.Lock()
 	defer s.mux.Unlock()
-	if !s.unsafeContains(removedEl) {
-		// short circuit
-		return
-	}
-	delete(s.existence, removedEl)
-	s.values = slices.DeleteFunc(s.values, func(e E) bool { return e == removedEl })
+	s.unsync.Remove(removedEl)
+}
+
+// Clear removes all elements from the set.
+// It is safe for concurrent use.
+func (s *OrderedSet[E]) Clear() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.unsync.Clear()
+}
+
+// Unsync returns a new [UnsyncOrderedSet] containing a copy of the elements of s, keeping their order.
+// s is left unchanged; use [OrderedSet.MoveToUnsync] to convert without copying. It is safe for concurrent use.
+func (s *OrderedSet[E]) Unsync() *UnsyncOrderedSet[E] {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return s.unsync.clone()
+}
+
+// MoveToUnsync moves the elements of s into a new [UnsyncOrderedSet] and leaves s empty.
+// The elements are not copied, so the conversion runs in O(1).
+// It is safe for concurrent use, but other goroutines that still use s observe it as empty afterwards.
+func (s *OrderedSet[E]) MoveToUnsync() *UnsyncOrderedSet[E] {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	ret := &UnsyncOrderedSet[E]{existence: s.unsync.existence, values: s.unsync.values}
+	s.unsync = UnsyncOrderedSet[E]{existence: nil, values: linkedList[E]{}}
+	return ret
 }
 
 // Diff returns a new OrderedSet containing elements that are in s or other but not in both.
+//
+// Deprecated: Use [OrderedSet.SymmetricDifference].
 func (s *OrderedSet[E]) Diff(other *OrderedSet[E]) *OrderedSet[E] {
+	return s.SymmetricDifference(other)
+}
+
+// SymmetricDifference returns a new OrderedSet containing elements that are in s or other but not in both.
+// Elements of s come first in their order, followed by elements of other in their order.
+func (s *OrderedSet[E]) SymmetricDifference(other *OrderedSet[E]) *OrderedSet[E] {
 	ret := NewOrderedSet[E]()
-	buildDiff(ret, s, other)
+	buildSymmetricDifference(ret, s, other)
+	return ret
+}
+
+// Difference returns a new OrderedSet containing elements that are in s but not in other.
+// The order of the elements in s is preserved.
+func (s *OrderedSet[E]) Difference(other *OrderedSet[E]) *OrderedSet[E] {
+	ret := NewOrderedSet[E]()
+	buildDifference(ret, s, other)
 	return ret
 }
 
@@ -105,3 +132,105 @@ func (s *OrderedSet[E]) Union(other *OrderedSet[E]) *OrderedSet[E] {
 	buildUnion(ret, s, other)
 	return ret
 }
+
+// IsSubset reports whether every element of s is also in other.
+func (s *OrderedSet[E]) IsSubset(other SetLike[E]) bool {
+	return IsSubset[E](s, other)
+}
+
+// IsProperSubset reports whether s is a subset of other and other has at least one element that s does not have.
+func (s *OrderedSet[E]) IsProperSubset(other SetLike[E]) bool {
+	return IsProperSubset[E](s, other)
+}
+
+// IsSuperset reports whether every element of other is also in s.
+func (s *OrderedSet[E]) IsSuperset(other SetLike[E]) bool {
+	return IsSuperset[E](s, other)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+func (s *OrderedSet[E]) IsDisjoint(other SetLike[E]) bool {
+	return IsDisjoint[E](s, other)
+}
+
+// Equal reports whether s and other contain the same elements, regardless of their order.
+func (s *OrderedSet[E]) Equal(other SetLike[E]) bool {
+	return Equal[E](s, other)
+}
+
+// EqualOrdered reports whether s and other contain the same elements in the same order.
+func (s *OrderedSet[E]) EqualOrdered(other *OrderedSet[E]) bool {
+	if s == other {
+		return true
+	}
+	return equalOrdered[E](s, other)
+}
+
+func (s *OrderedSet[E]) isSelf(other SetLike[E]) bool {
+	o, ok := unwrapView(other).(*OrderedSet[E])
+	return ok && o == s
+}
+
+// UnionWith adds all elements of other to s.
+// The elements of s keep their order, and the added elements follow in the iteration order of other.
+// It is safe for concurrent use.
+func (s *OrderedSet[E]) UnionWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		return
+	}
+	values := detachedValues(other)
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for el := range values {
+		s.unsync.Append(el)
+	}
+}
+
+// IntersectWith removes the elements of s that are not present in other.
+// The remaining elements keep their order.
+// It is safe for concurrent use.
+func (s *OrderedSet[E]) IntersectWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		return
+	}
+	other = detachedSetLike(other)
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.unsync.IntersectWith(other)
+}
+
+// DifferenceWith removes the elements of s that are present in other.
+// The remaining elements keep their order.
+// It is safe for concurrent use.
+func (s *OrderedSet[E]) DifferenceWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		s.Clear()
+		return
+	}
+	values := detachedValues(other)
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for el := range values {
+		s.unsync.Remove(el)
+	}
+}
+
+// SymmetricDifferenceWith updates s to contain the elements that are in s or other but not in both.
+// The remaining elements of s keep their order, and the added elements follow in the iteration order of other.
+// It is safe for concurrent use.
+func (s *OrderedSet[E]) SymmetricDifferenceWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		s.Clear()
+		return
+	}
+	values := detachedValues(other)
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for el := range values {
+		if s.unsync.Contains(el) {
+			s.unsync.Remove(el)
+		} else {
+			s.unsync.Append(el)
+		}
+	}
+}
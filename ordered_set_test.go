@@ -3,6 +3,8 @@ package coll_test
 import (
 	"reflect"
 	"slices"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/aereal/coll"
@@ -53,7 +55,7 @@ func TestOrderedSet(t *testing.T) {
 	}
 }
 
-func TestOrderedSet_Diff(t *testing.T) {
+func TestOrderedSet_SymmetricDifference(t *testing.T) {
 	testCases := []struct {
 		lhs  *coll.OrderedSet[string]
 		rhs  *coll.OrderedSet[string]
@@ -84,10 +86,70 @@ func TestOrderedSet_Diff(t *testing.T) {
 			rhs:  coll.NewOrderedSet("a", "b", "c"),
 			want: coll.NewOrderedSet("b", "c"),
 		},
+		{
+			name: "both have their own elements",
+			lhs:  coll.NewOrderedSet("a", "b"),
+			rhs:  coll.NewOrderedSet("b", "c", "d"),
+			want: coll.NewOrderedSet("a", "c", "d"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.lhs.SymmetricDifference(tc.rhs)
+			gotSlice := slices.Collect(got.Values())
+			wantSlice := slices.Collect(tc.want.Values())
+			if !reflect.DeepEqual(wantSlice, gotSlice) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", wantSlice, gotSlice)
+			}
+		})
+	}
+}
+
+func TestOrderedSet_Diff(t *testing.T) {
+	lhs := coll.NewOrderedSet("a", "b")
+	rhs := coll.NewOrderedSet("b", "c", "d")
+	got := slices.Collect(lhs.Diff(rhs).Values())
+	want := slices.Collect(lhs.SymmetricDifference(rhs).Values())
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+func TestOrderedSet_Difference(t *testing.T) {
+	testCases := []struct {
+		lhs  *coll.OrderedSet[string]
+		rhs  *coll.OrderedSet[string]
+		want *coll.OrderedSet[string]
+		name string
+	}{
+		{
+			name: "empty vs empty",
+			lhs:  coll.NewOrderedSet[string](),
+			rhs:  coll.NewOrderedSet[string](),
+			want: coll.NewOrderedSet[string](),
+		},
+		{
+			name: "lhs == rhs",
+			lhs:  coll.NewOrderedSet("a", "b", "c"),
+			rhs:  coll.NewOrderedSet("a", "b", "c"),
+			want: coll.NewOrderedSet[string](),
+		},
+		{
+			name: "lhs > rhs",
+			lhs:  coll.NewOrderedSet("a", "b", "c"),
+			rhs:  coll.NewOrderedSet("b"),
+			want: coll.NewOrderedSet("a", "c"),
+		},
+		{
+			name: "lhs < rhs",
+			lhs:  coll.NewOrderedSet("a", "d"),
+			rhs:  coll.NewOrderedSet("a", "b", "c"),
+			want: coll.NewOrderedSet("d"),
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			got := tc.lhs.Diff(tc.rhs)
+			got := tc.lhs.Difference(tc.rhs)
 			gotSlice := slices.Collect(got.Values())
 			wantSlice := slices.Collect(tc.want.Values())
 			if !reflect.DeepEqual(wantSlice, gotSlice) {
@@ -191,3 +253,274 @@ func TestOrderedSet_empty(t *testing.T) {
 		t.Errorf("Contains(42) reports true unexpectedly")
 	}
 }
+
+func TestOrderedSet_concurrentAccess(t *testing.T) {
+	nums := coll.NewOrderedSet[int]()
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range 100 {
+				nums.Append(i*100 + n)
+				if n%3 == 0 {
+					nums.Remove(i*100 + n)
+				}
+			}
+		}()
+	}
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_ = nums.Len()
+				_ = nums.Contains(42)
+				for n := range nums.Values() {
+					_ = n
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if got := nums.Len(); got != 4*66 {
+		t.Errorf("Len() returns unexpected value: %d", got)
+	}
+}
+
+func TestOrderedSet_Remove_preservesOrder(t *testing.T) {
+	strSet := coll.NewOrderedSet("a", "b", "c", "d")
+	strSet.Remove("b")
+	strSet.Remove("d")
+	strSet.Append("b")
+	got := slices.Collect(strSet.Values())
+	want := []string{"a", "c", "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+// BenchmarkOrderedSet_Remove compares OrderedSet.Remove with the slice-backed removal it replaced.
+func BenchmarkOrderedSet_Remove(b *testing.B) {
+	for _, size := range []int{1_000, 100_000, 1_000_000} {
+		b.Run("slices.DeleteFunc/"+strconv.Itoa(size), func(b *testing.B) {
+			existence := make(map[int]struct{}, size)
+			values := make([]int, 0, size)
+			for i := range size {
+				existence[i] = struct{}{}
+				values = append(values, i)
+			}
+			b.ResetTimer()
+			for i := range b.N {
+				el := (i * 7919) % size
+				delete(existence, el)
+				values = slices.DeleteFunc(values, func(e int) bool { return e == el })
+				existence[el] = struct{}{}
+				values = append(values, el)
+			}
+		})
+		b.Run("OrderedSet/"+strconv.Itoa(size), func(b *testing.B) {
+			nums := coll.NewOrderedSet[int]()
+			for i := range size {
+				nums.Append(i)
+			}
+			b.ResetTimer()
+			for i := range b.N {
+				el := (i * 7919) % size
+				nums.Remove(el)
+				nums.Append(el)
+			}
+		})
+	}
+}
+
+func TestOrderedSet_predicates(t *testing.T) {
+	xs := coll.NewOrderedSet("a", "b")
+	if !xs.IsSubset(coll.NewSet("b", "a", "c")) {
+		t.Error("IsSubset() reports false unexpectedly")
+	}
+	if !xs.IsProperSubset(coll.NewOrderedSet("a", "b", "c")) {
+		t.Error("IsProperSubset() reports false unexpectedly")
+	}
+	if !xs.IsSuperset(coll.NewOrderedSet("a")) {
+		t.Error("IsSuperset() reports false unexpectedly")
+	}
+	if !xs.IsDisjoint(coll.NewOrderedSet("c")) {
+		t.Error("IsDisjoint() reports false unexpectedly")
+	}
+	if !xs.Equal(coll.NewOrderedSet("b", "a")) {
+		t.Error("Equal() reports false unexpectedly")
+	}
+}
+
+func TestOrderedSet_EqualOrdered(t *testing.T) {
+	testCases := []struct {
+		lhs  *coll.OrderedSet[string]
+		rhs  *coll.OrderedSet[string]
+		name string
+		want bool
+	}{
+		{
+			name: "empty vs empty",
+			lhs:  coll.NewOrderedSet[string](),
+			rhs:  coll.NewOrderedSet[string](),
+			want: true,
+		},
+		{
+			name: "same order",
+			lhs:  coll.NewOrderedSet("a", "b", "c"),
+			rhs:  coll.NewOrderedSet("a", "b", "c"),
+			want: true,
+		},
+		{
+			name: "different order",
+			lhs:  coll.NewOrderedSet("a", "b", "c"),
+			rhs:  coll.NewOrderedSet("c", "b", "a"),
+			want: false,
+		},
+		{
+			name: "different length",
+			lhs:  coll.NewOrderedSet("a", "b"),
+			rhs:  coll.NewOrderedSet("a", "b", "c"),
+			want: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.lhs.EqualOrdered(tc.rhs); got != tc.want {
+				t.Errorf("EqualOrdered() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOrderedSet_inPlaceOperations(t *testing.T) {
+	testCases := []struct {
+		apply func(s *coll.OrderedSet[string], other coll.SetLike[string])
+		name  string
+		want  []string
+	}{
+		{
+			name:  "UnionWith",
+			apply: (*coll.OrderedSet[string]).UnionWith,
+			want:  []string{"c", "a", "b", "d"},
+		},
+		{
+			name:  "IntersectWith",
+			apply: (*coll.OrderedSet[string]).IntersectWith,
+			want:  []string{"c", "b"},
+		},
+		{
+			name:  "DifferenceWith",
+			apply: (*coll.OrderedSet[string]).DifferenceWith,
+			want:  []string{"a"},
+		},
+		{
+			name:  "SymmetricDifferenceWith",
+			apply: (*coll.OrderedSet[string]).SymmetricDifferenceWith,
+			want:  []string{"a", "d"},
+		},
+	}
+	// the locked sets are copied before s is locked, while the lock-free ones are read directly
+	others := map[string]func() coll.SetLike[string]{
+		"OrderedSet":           func() coll.SetLike[string] { return coll.NewOrderedSet("b", "c", "d") },
+		"UnsyncOrderedSet":     func() coll.SetLike[string] { return coll.NewUnsyncOrderedSet("b", "c", "d") },
+		"PersistentOrderedSet": func() coll.SetLike[string] { return coll.NewPersistentOrderedSet("b", "c", "d") },
+	}
+	for _, tc := range testCases {
+		for otherName, newOther := range others {
+			t.Run(tc.name+"/"+otherName, func(t *testing.T) {
+				s := coll.NewOrderedSet("c", "a", "b")
+				tc.apply(s, newOther())
+				got := slices.Collect(s.Values())
+				if !reflect.DeepEqual(tc.want, got) {
+					t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, got)
+				}
+			})
+		}
+	}
+}
+
+func TestOrderedSet_inPlaceOperations_self(t *testing.T) {
+	testCases := []struct {
+		apply func(s *coll.OrderedSet[string], other coll.SetLike[string])
+		name  string
+		want  []string
+	}{
+		{
+			name:  "UnionWith",
+			apply: (*coll.OrderedSet[string]).UnionWith,
+			want:  []string{"a", "b"},
+		},
+		{
+			name:  "IntersectWith",
+			apply: (*coll.OrderedSet[string]).IntersectWith,
+			want:  []string{"a", "b"},
+		},
+		{
+			name:  "DifferenceWith",
+			apply: (*coll.OrderedSet[string]).DifferenceWith,
+			want:  nil,
+		},
+		{
+			name:  "SymmetricDifferenceWith",
+			apply: (*coll.OrderedSet[string]).SymmetricDifferenceWith,
+			want:  nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := coll.NewOrderedSet("a", "b")
+			tc.apply(s, s)
+			got := slices.Collect(s.Values())
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestOrderedSet_inPlaceOperations_concurrent(t *testing.T) {
+	ops := map[string]func(s *coll.OrderedSet[int], other coll.SetLike[int]){
+		"UnionWith":               (*coll.OrderedSet[int]).UnionWith,
+		"IntersectWith":           (*coll.OrderedSet[int]).IntersectWith,
+		"DifferenceWith":          (*coll.OrderedSet[int]).DifferenceWith,
+		"SymmetricDifferenceWith": (*coll.OrderedSet[int]).SymmetricDifferenceWith,
+	}
+	for name, apply := range ops {
+		t.Run(name, func(t *testing.T) {
+			a, b := coll.NewOrderedSet[int](), coll.NewOrderedSet[int]()
+			for i := range 1000 {
+				a.Append(i)
+				b.Append(i + 500)
+			}
+			// the two calls lock a and b in opposite orders if the write lock is held while reading other
+			var wg sync.WaitGroup
+			for _, pair := range [][2]*coll.OrderedSet[int]{{a, b}, {b, a}} {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range 100 {
+						apply(pair[0], pair[1])
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestOrderedSet_Clear(t *testing.T) {
+	strSet := coll.NewOrderedSet("a", "b")
+	strSet.Clear()
+	if gotLen := strSet.Len(); gotLen != 0 {
+		t.Errorf("Len() returns unexpected value: %d", gotLen)
+	}
+	if strSet.Contains("a") {
+		t.Error("the set says it DOES contain 'a'")
+	}
+	strSet.Append("c")
+	if got, want := slices.Collect(strSet.Values()), []string{"c"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
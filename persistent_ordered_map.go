@@ -0,0 +1,87 @@
+package coll
+
+import "iter"
+
+// NewPersistentOrderedMap returns a new, empty [PersistentOrderedMap].
+func NewPersistentOrderedMap[K comparable, V any]() *PersistentOrderedMap[K, V] {
+	return &PersistentOrderedMap[K, V]{order: nil, index: hamt[K, sequencedValue[V]]{root: nil, len: 0}, next: 0}
+}
+
+// PersistentOrderedMap represents an immutable map that preserves insertion order of keys.
+// It is safe for concurrent use since it is never modified; [PersistentOrderedMap.With] and [PersistentOrderedMap.Without]
+// return a new version in O(log n) that shares most of its structure with the old one, which stays unchanged.
+// The zero value is an empty map.
+//
+// It is built on a hash array mapped trie from the keys to their values and sequence numbers,
+// and a balanced tree from the sequence numbers to the keys that gives the insertion order.
+type PersistentOrderedMap[K comparable, V any] struct {
+	order *seqTree[K]
+	index hamt[K, sequencedValue[V]]
+	next  uint64
+}
+
+// sequencedValue is a value stamped with the sequence number at which its key was inserted.
+type sequencedValue[V any] struct {
+	value V
+	seq   uint64
+}
+
+// Len returns the number of entries in the map.
+func (m *PersistentOrderedMap[K, V]) Len() int { return m.index.len }
+
+// Get retrieves the value associated with the given key.
+// The second return value indicates whether the key was found.
+func (m *PersistentOrderedMap[K, V]) Get(key K) (V, bool) {
+	sv, found := m.index.get(key)
+	return sv.value, found
+}
+
+// With returns a version of the map in which the key is associated with the value.
+// If the key already exists, its value is replaced and the key keeps its position; otherwise the key is appended.
+func (m *PersistentOrderedMap[K, V]) With(key K, value V) *PersistentOrderedMap[K, V] {
+	if prev, found := m.index.get(key); found {
+		index, _ := m.index.set(key, sequencedValue[V]{value: value, seq: prev.seq})
+		return &PersistentOrderedMap[K, V]{order: m.order, index: index, next: m.next}
+	}
+	index, _ := m.index.set(key, sequencedValue[V]{value: value, seq: m.next})
+	return &PersistentOrderedMap[K, V]{order: m.order.insert(m.next, key), index: index, next: m.next + 1}
+}
+
+// Without returns a version of the map that does not contain the key.
+// The order of the remaining keys is preserved. It returns m itself if the key does not exist.
+func (m *PersistentOrderedMap[K, V]) Without(key K) *PersistentOrderedMap[K, V] {
+	prev, found := m.index.get(key)
+	if !found {
+		return m
+	}
+	index, _ := m.index.delete(key)
+	return &PersistentOrderedMap[K, V]{order: m.order.delete(prev.seq), index: index, next: m.next}
+}
+
+// Keys returns an iterator over the keys in insertion order.
+func (m *PersistentOrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.order.all(yield)
+	}
+}
+
+// Values returns an iterator over the values in insertion order of their corresponding keys.
+func (m *PersistentOrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range m.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over key-value pairs in insertion order.
+func (m *PersistentOrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.order.all(func(key K) bool {
+			sv, _ := m.index.get(key)
+			return yield(key, sv.value)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package coll_test
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func TestPersistentOrderedMap(t *testing.T) {
+	v1 := coll.NewPersistentOrderedMap[string, int]().With("b", 1).With("a", 2)
+	v2 := v1.With("b", 10) // keeps the position
+	v3 := v2.Without("b")
+	v4 := v3.With("b", 100) // appended again
+	testCases := []struct {
+		m          *coll.PersistentOrderedMap[string, int]
+		name       string
+		wantKeys   []string
+		wantValues []int
+	}{
+		{name: "v1", m: v1, wantKeys: []string{"b", "a"}, wantValues: []int{1, 2}},
+		{name: "v2", m: v2, wantKeys: []string{"b", "a"}, wantValues: []int{10, 2}},
+		{name: "v3", m: v3, wantKeys: []string{"a"}, wantValues: []int{2}},
+		{name: "v4", m: v4, wantKeys: []string{"a", "b"}, wantValues: []int{2, 100}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := slices.Collect(tc.m.Keys()); !reflect.DeepEqual(tc.wantKeys, got) {
+				t.Errorf("Keys: got %v, want %v", got, tc.wantKeys)
+			}
+			if got := slices.Collect(tc.m.Values()); !reflect.DeepEqual(tc.wantValues, got) {
+				t.Errorf("Values: got %v, want %v", got, tc.wantValues)
+			}
+			if got := tc.m.Len(); got != len(tc.wantKeys) {
+				t.Errorf("Len: got %d, want %d", got, len(tc.wantKeys))
+			}
+			for i, key := range tc.wantKeys {
+				if got, found := tc.m.Get(key); !found || got != tc.wantValues[i] {
+					t.Errorf("Get(%s): got (%v, %v)", key, got, found)
+				}
+			}
+		})
+	}
+	if v3.Without("x") != v3 {
+		t.Error("Without() returns a new version for a missing key")
+	}
+}
+
+func TestPersistentOrderedMap_empty(t *testing.T) {
+	var m coll.PersistentOrderedMap[string, int]
+	if _, found := m.Get("a"); found {
+		t.Error("Get(a) reports found unexpectedly")
+	}
+	if got, _ := m.With("a", 1).Get("a"); got != 1 {
+		t.Errorf("Get(a): got %v, want 1", got)
+	}
+}
+
+func TestPersistentOrderedMap_versions(t *testing.T) {
+	rng := rand.New(rand.NewPCG(5, 6))
+	m := coll.NewPersistentOrderedMap[int, int]()
+	var (
+		modelKeys []int
+		modelVals = map[int]int{}
+	)
+	for i := range 3000 {
+		key := rng.IntN(500)
+		if rng.IntN(3) == 0 {
+			m = m.Without(key)
+			modelKeys = slices.DeleteFunc(modelKeys, func(x int) bool { return x == key })
+			delete(modelVals, key)
+			continue
+		}
+		m = m.With(key, i)
+		if _, found := modelVals[key]; !found {
+			modelKeys = append(modelKeys, key)
+		}
+		modelVals[key] = i
+	}
+	for key, value := range m.All() {
+		if modelVals[key] != value {
+			t.Errorf("All: %d is associated with %d, want %d", key, value, modelVals[key])
+		}
+	}
+	if got := slices.Collect(m.Keys()); !reflect.DeepEqual(modelKeys, got) {
+		t.Error("Keys mismatch")
+	}
+}
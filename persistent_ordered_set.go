@@ -0,0 +1,108 @@
+package coll
+
+import "iter"
+
+// NewPersistentOrderedSet returns a new [PersistentOrderedSet] containing the provided elements.
+// Duplicates in the input are ignored, and insertion order is preserved.
+func NewPersistentOrderedSet[E comparable](els ...E) *PersistentOrderedSet[E] {
+	s := &PersistentOrderedSet[E]{order: nil, index: hamt[E, uint64]{root: nil, len: 0}, next: 0}
+	for _, el := range els {
+		s.unsafeAppend(el)
+	}
+	return s
+}
+
+// PersistentOrderedSet represents an immutable set of comparable elements that maintains insertion order.
+// It is safe for concurrent use since it is never modified; [PersistentOrderedSet.With] and [PersistentOrderedSet.Without]
+// return a new version in O(log n) that shares most of its structure with the old one, which stays unchanged.
+// The zero value is an empty set.
+//
+// It is built on a hash array mapped trie from the elements to their sequence numbers,
+// and a balanced tree from the sequence numbers to the elements that gives the insertion order.
+type PersistentOrderedSet[E comparable] struct {
+	order *seqTree[E]
+	index hamt[E, uint64]
+	next  uint64
+}
+
+// unsafeAppend appends el to s in place. It must be called only while building a new version.
+func (s *PersistentOrderedSet[E]) unsafeAppend(el E) bool {
+	index, added := s.index.set(el, s.next)
+	if !added {
+		return false
+	}
+	s.index = index
+	s.order = s.order.insert(s.next, el)
+	s.next++
+	return true
+}
+
+// Len returns the number of elements in the set.
+func (s *PersistentOrderedSet[E]) Len() int { return s.index.len }
+
+// Contains reports whether the element is present in the set.
+func (s *PersistentOrderedSet[E]) Contains(el E) bool {
+	_, found := s.index.get(el)
+	return found
+}
+
+// Values returns an iterator over the elements of the set in insertion order.
+func (s *PersistentOrderedSet[E]) Values() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		s.order.all(yield)
+	}
+}
+
+// With returns a version of the set that contains the element, appended at the end if it is new.
+// It returns s itself if the element already exists.
+func (s *PersistentOrderedSet[E]) With(el E) *PersistentOrderedSet[E] {
+	ret := *s
+	if !ret.unsafeAppend(el) {
+		return s
+	}
+	return &ret
+}
+
+// Without returns a version of the set that does not contain the element.
+// The order of the remaining elements is preserved. It returns s itself if the element does not exist.
+func (s *PersistentOrderedSet[E]) Without(el E) *PersistentOrderedSet[E] {
+	seq, found := s.index.get(el)
+	if !found {
+		return s
+	}
+	index, _ := s.index.delete(el)
+	return &PersistentOrderedSet[E]{order: s.order.delete(seq), index: index, next: s.next}
+}
+
+// IsSubset reports whether every element of s is also in other.
+func (s *PersistentOrderedSet[E]) IsSubset(other SetLike[E]) bool {
+	return IsSubset[E](s, other)
+}
+
+// IsProperSubset reports whether s is a subset of other and other has at least one element that s does not have.
+func (s *PersistentOrderedSet[E]) IsProperSubset(other SetLike[E]) bool {
+	return IsProperSubset[E](s, other)
+}
+
+// IsSuperset reports whether every element of other is also in s.
+func (s *PersistentOrderedSet[E]) IsSuperset(other SetLike[E]) bool {
+	return IsSuperset[E](s, other)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+func (s *PersistentOrderedSet[E]) IsDisjoint(other SetLike[E]) bool {
+	return IsDisjoint[E](s, other)
+}
+
+// Equal reports whether s and other contain the same elements, regardless of their order.
+func (s *PersistentOrderedSet[E]) Equal(other SetLike[E]) bool {
+	return Equal[E](s, other)
+}
+
+// EqualOrdered reports whether s and other contain the same elements in the same order.
+func (s *PersistentOrderedSet[E]) EqualOrdered(other *PersistentOrderedSet[E]) bool {
+	if s == other {
+		return true
+	}
+	return equalOrdered[E](s, other)
+}
@@ -0,0 +1,96 @@
+package coll_test
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func TestPersistentOrderedSet(t *testing.T) {
+	v1 := coll.NewPersistentOrderedSet("c", "a", "b", "a")
+	v2 := v1.With("z")
+	v3 := v2.Without("a")
+	v4 := v3.With("a")
+	testCases := []struct {
+		set  *coll.PersistentOrderedSet[string]
+		name string
+		want []string
+	}{
+		{name: "v1", set: v1, want: []string{"c", "a", "b"}},
+		{name: "v2", set: v2, want: []string{"c", "a", "b", "z"}},
+		{name: "v3", set: v3, want: []string{"c", "b", "z"}},
+		{name: "v4", set: v4, want: []string{"c", "b", "z", "a"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := slices.Collect(tc.set.Values()); !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, got)
+			}
+			if got := tc.set.Len(); got != len(tc.want) {
+				t.Errorf("Len() = %d, want %d", got, len(tc.want))
+			}
+		})
+	}
+	if v1.With("a") != v1 || v1.Without("x") != v1 {
+		t.Error("a no-op update returns a new version")
+	}
+	if !v1.EqualOrdered(coll.NewPersistentOrderedSet("c", "a", "b")) || v1.EqualOrdered(v4) {
+		t.Error("EqualOrdered() reports unexpected results")
+	}
+}
+
+func TestPersistentOrderedSet_empty(t *testing.T) {
+	var s coll.PersistentOrderedSet[int]
+	if s.Contains(42) || s.Len() != 0 {
+		t.Error("the zero value is not empty")
+	}
+	if got, want := slices.Collect(s.With(2).With(1).Values()), []int{2, 1}; !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+func TestPersistentOrderedSet_versions(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	s := coll.NewPersistentOrderedSet[int]()
+	var model []int
+	type version struct {
+		set   *coll.PersistentOrderedSet[int]
+		model []int
+	}
+	var versions []version
+	for i := range 3000 {
+		el := rng.IntN(500)
+		if rng.IntN(3) == 0 {
+			s = s.Without(el)
+			model = slices.DeleteFunc(model, func(x int) bool { return x == el })
+		} else {
+			s = s.With(el)
+			if !slices.Contains(model, el) {
+				model = append(model, el)
+			}
+		}
+		if i%300 == 0 {
+			versions = append(versions, version{set: s, model: slices.Clone(model)})
+		}
+	}
+	versions = append(versions, version{set: s, model: model})
+	for i, v := range versions {
+		if got := slices.Collect(v.set.Values()); !reflect.DeepEqual(v.model, got) {
+			t.Errorf("version #%d: Values() mismatch", i)
+		}
+	}
+}
+
+func TestPersistentOrderedSet_setOperations(t *testing.T) {
+	xs := coll.NewPersistentOrderedSet("c", "b", "a")
+	got := coll.Union[string](xs, coll.NewSet("d"))
+	if _, ok := got.(*coll.OrderedSet[string]); !ok {
+		t.Errorf("Union() returns %T; want *coll.OrderedSet[string]", got)
+	}
+	if want, got := []string{"c", "b", "a", "d"}, slices.Collect(got.Values()); !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
@@ -0,0 +1,88 @@
+package coll
+
+import "iter"
+
+// NewPersistentSet returns a new [PersistentSet] containing the provided elements.
+// Duplicates in the input are ignored.
+func NewPersistentSet[E comparable](els ...E) *PersistentSet[E] {
+	s := &PersistentSet[E]{trie: hamt[E, struct{}]{root: nil, len: 0}}
+	for _, el := range els {
+		s.trie, _ = s.trie.set(el, struct{}{})
+	}
+	return s
+}
+
+// PersistentSet represents an immutable set of comparable elements.
+// It is safe for concurrent use since it is never modified; [PersistentSet.With] and [PersistentSet.Without]
+// return a new version in O(log n) that shares most of its structure with the old one, which stays unchanged.
+// The zero value is an empty set.
+//
+// It is built on a hash array mapped trie.
+type PersistentSet[E comparable] struct {
+	trie hamt[E, struct{}]
+}
+
+// Len returns the number of elements in the set.
+func (s *PersistentSet[E]) Len() int { return s.trie.len }
+
+// Contains reports whether the element is present in the set.
+func (s *PersistentSet[E]) Contains(el E) bool {
+	_, found := s.trie.get(el)
+	return found
+}
+
+// Values returns an iterator over the elements of the set.
+func (s *PersistentSet[E]) Values() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for el := range s.trie.all() {
+			if !yield(el) {
+				return
+			}
+		}
+	}
+}
+
+// With returns a version of the set that contains the element.
+// It returns s itself if the element already exists.
+func (s *PersistentSet[E]) With(el E) *PersistentSet[E] {
+	trie, added := s.trie.set(el, struct{}{})
+	if !added {
+		return s
+	}
+	return &PersistentSet[E]{trie: trie}
+}
+
+// Without returns a version of the set that does not contain the element.
+// It returns s itself if the element does not exist.
+func (s *PersistentSet[E]) Without(el E) *PersistentSet[E] {
+	trie, found := s.trie.delete(el)
+	if !found {
+		return s
+	}
+	return &PersistentSet[E]{trie: trie}
+}
+
+// IsSubset reports whether every element of s is also in other.
+func (s *PersistentSet[E]) IsSubset(other SetLike[E]) bool {
+	return IsSubset[E](s, other)
+}
+
+// IsProperSubset reports whether s is a subset of other and other has at least one element that s does not have.
+func (s *PersistentSet[E]) IsProperSubset(other SetLike[E]) bool {
+	return IsProperSubset[E](s, other)
+}
+
+// IsSuperset reports whether every element of other is also in s.
+func (s *PersistentSet[E]) IsSuperset(other SetLike[E]) bool {
+	return IsSuperset[E](s, other)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+func (s *PersistentSet[E]) IsDisjoint(other SetLike[E]) bool {
+	return IsDisjoint[E](s, other)
+}
+
+// Equal reports whether s and other contain the same elements, regardless of their order.
+func (s *PersistentSet[E]) Equal(other SetLike[E]) bool {
+	return Equal[E](s, other)
+}
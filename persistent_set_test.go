@@ -0,0 +1,131 @@
+package coll_test
+
+import (
+	"maps"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func TestPersistentSet(t *testing.T) {
+	v1 := coll.NewPersistentSet("c", "a", "b", "a")
+	if gotLen := v1.Len(); gotLen != 3 {
+		t.Errorf("Len() returns unexpected value: %d", gotLen)
+	}
+	v2 := v1.With("z")
+	v3 := v2.Without("a")
+	if got, want := slices.Sorted(v1.Values()), []string{"a", "b", "c"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("v1 is changed:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if got, want := slices.Sorted(v2.Values()), []string{"a", "b", "c", "z"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("v2: mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if got, want := slices.Sorted(v3.Values()), []string{"b", "c", "z"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("v3: mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if !v2.Contains("a") || v3.Contains("a") {
+		t.Error("Contains() reports unexpected results")
+	}
+	if v1.With("a") != v1 {
+		t.Error("With() returns a new version for an existing element")
+	}
+	if v1.Without("x") != v1 {
+		t.Error("Without() returns a new version for a missing element")
+	}
+}
+
+func TestPersistentSet_empty(t *testing.T) {
+	var s coll.PersistentSet[int]
+	if s.Contains(42) || s.Len() != 0 {
+		t.Error("the zero value is not empty")
+	}
+	if got := s.Without(42).Len(); got != 0 {
+		t.Errorf("Len() returns unexpected value: %d", got)
+	}
+	if got := s.With(42); !got.Contains(42) || s.Contains(42) {
+		t.Error("With() on the zero value returns unexpected result")
+	}
+}
+
+func TestPersistentSet_versions(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	s := coll.NewPersistentSet[int]()
+	model := map[int]struct{}{}
+	type version struct {
+		set   *coll.PersistentSet[int]
+		model map[int]struct{}
+	}
+	var versions []version
+	for i := range 5000 {
+		el := rng.IntN(2000)
+		if rng.IntN(3) == 0 {
+			s = s.Without(el)
+			delete(model, el)
+		} else {
+			s = s.With(el)
+			model[el] = struct{}{}
+		}
+		if i%500 == 0 {
+			versions = append(versions, version{set: s, model: maps.Clone(model)})
+		}
+	}
+	versions = append(versions, version{set: s, model: model})
+	for i, v := range versions {
+		want := slices.Sorted(maps.Keys(v.model))
+		if got := slices.Sorted(v.set.Values()); !reflect.DeepEqual(want, got) {
+			t.Errorf("version #%d: Values() mismatch", i)
+		}
+		if got := v.set.Len(); got != len(want) {
+			t.Errorf("version #%d: Len() = %d, want %d", i, got, len(want))
+		}
+		for el := range 2000 {
+			if _, want := v.model[el]; v.set.Contains(el) != want {
+				t.Errorf("version #%d: Contains(%d) = %v", i, el, !want)
+			}
+		}
+	}
+}
+
+func TestPersistentSet_setOperations(t *testing.T) {
+	xs := coll.NewPersistentSet("a", "b", "c")
+	ys := coll.NewPersistentSet("b", "c", "d")
+	if got, want := slices.Sorted(coll.Intersect[string](xs, ys).Values()), []string{"b", "c"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Intersect(): mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if !xs.IsSuperset(coll.NewSet("a")) || xs.IsDisjoint(ys) || !xs.Equal(coll.NewOrderedSet("c", "b", "a")) {
+		t.Error("the predicates report unexpected results")
+	}
+	if !xs.IsSubset(xs.With("z")) || !xs.IsProperSubset(xs.With("z")) {
+		t.Error("the subset predicates report unexpected results")
+	}
+}
+
+func BenchmarkPersistentSet_With(b *testing.B) {
+	for _, size := range []int{1_000, 100_000} {
+		b.Run("PersistentSet/"+strconv.Itoa(size), func(b *testing.B) {
+			s := coll.NewPersistentSet[int]()
+			for i := range size {
+				s = s.With(i)
+			}
+			b.ResetTimer()
+			for i := range b.N {
+				_ = s.With(size + i)
+			}
+		})
+		b.Run("copied Set/"+strconv.Itoa(size), func(b *testing.B) {
+			s := coll.NewSet[int]()
+			for i := range size {
+				s.Append(i)
+			}
+			b.ResetTimer()
+			for i := range b.N {
+				next := coll.NewSet(slices.Collect(s.Values())...)
+				next.Append(size + i)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package coll
+
+// seqTree is a persistent AVL tree keyed by sequence numbers, used to keep the insertion order of the persistent ordered collections.
+// Updates copy only the path from the root to the updated node and share the rest with the old tree,
+// so a tree is never modified once created. A nil tree is empty.
+type seqTree[V any] struct {
+	left   *seqTree[V]
+	right  *seqTree[V]
+	value  V
+	seq    uint64
+	height int
+}
+
+func newSeqTree[V any](left, right *seqTree[V], seq uint64, value V) *seqTree[V] {
+	return &seqTree[V]{left: left, right: right, value: value, seq: seq, height: 1 + max(left.heightOf(), right.heightOf())}
+}
+
+func (t *seqTree[V]) heightOf() int {
+	if t == nil {
+		return 0
+	}
+	return t.height
+}
+
+// insert returns a tree in which seq is associated with value.
+func (t *seqTree[V]) insert(seq uint64, value V) *seqTree[V] {
+	switch {
+	case t == nil:
+		return newSeqTree(nil, nil, seq, value)
+	case seq < t.seq:
+		return balanceSeqTree(t.left.insert(seq, value), t.right, t.seq, t.value)
+	case seq > t.seq:
+		return balanceSeqTree(t.left, t.right.insert(seq, value), t.seq, t.value)
+	default:
+		return newSeqTree(t.left, t.right, seq, value)
+	}
+}
+
+// delete returns a tree without seq.
+func (t *seqTree[V]) delete(seq uint64) *seqTree[V] {
+	switch {
+	case t == nil:
+		return nil
+	case seq < t.seq:
+		return balanceSeqTree(t.left.delete(seq), t.right, t.seq, t.value)
+	case seq > t.seq:
+		return balanceSeqTree(t.left, t.right.delete(seq), t.seq, t.value)
+	case t.left == nil:
+		return t.right
+	case t.right == nil:
+		return t.left
+	default:
+		successor := t.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		return balanceSeqTree(t.left, t.right.delete(successor.seq), successor.seq, successor.value)
+	}
+}
+
+// balanceSeqTree returns a tree made of left, right and the node of seq, rotated if the heights of left and right differ by more than one.
+func balanceSeqTree[V any](left, right *seqTree[V], seq uint64, value V) *seqTree[V] {
+	switch hl, hr := left.heightOf(), right.heightOf(); {
+	case hl > hr+1:
+		if left.left.heightOf() >= left.right.heightOf() {
+			return newSeqTree(left.left, newSeqTree(left.right, right, seq, value), left.seq, left.value)
+		}
+		pivot := left.right
+		return newSeqTree(newSeqTree(left.left, pivot.left, left.seq, left.value), newSeqTree(pivot.right, right, seq, value), pivot.seq, pivot.value)
+	case hr > hl+1:
+		if right.right.heightOf() >= right.left.heightOf() {
+			return newSeqTree(newSeqTree(left, right.left, seq, value), right.right, right.seq, right.value)
+		}
+		pivot := right.left
+		return newSeqTree(newSeqTree(left, pivot.left, seq, value), newSeqTree(pivot.right, right.right, right.seq, right.value), pivot.seq, pivot.value)
+	default:
+		return newSeqTree(left, right, seq, value)
+	}
+}
+
+// all calls yield for each value in ascending order of the sequence numbers until yield returns false.
+func (t *seqTree[V]) all(yield func(V) bool) bool {
+	if t == nil {
+		return true
+	}
+	return t.left.all(yield) && yield(t.value) && t.right.all(yield)
+}
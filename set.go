@@ -9,39 +9,38 @@ import (
 // Duplicates in the input are ignored.
 func NewSet[E comparable](els ...E) *Set[E] {
 	s := &Set[E]{
-		values: map[E]struct{}{},
+		unsync: *NewUnsyncSet(els...),
 		mux:    sync.RWMutex{},
 	}
-	for _, v := range els {
-		s.unsafeAppend(v)
-	}
 	return s
 }
 
 // Set represents a set of comparable elements.
 // It is safe for concurrent use.
+//
+// Iterators returned by the set hold the read lock for the duration of the iteration,
+// so the set must not be modified from within the loop body.
+// The in-place operations such as [Set.UnionWith] read their argument before taking the write lock,
+// copying its elements unless it is lock-free like an [UnsyncSet] or a persistent set.
 type Set[E comparable] struct {
-	values map[E]struct{}
+	unsync UnsyncSet[E]
 	mux    sync.RWMutex
 }
 
 // Len returns the number of elements in the set.
-func (s *Set[E]) Len() int { return len(s.values) }
+// It is safe for concurrent use.
+func (s *Set[E]) Len() int {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return s.unsync.Len()
+}
 
 // Contains reports whether the element is present in the set.
 // It is safe for concurrent use.
 func (s *Set[E]) Contains(el E) bool {
 	s.mux.RLock()
 	defer s.mux.RUnlock()
-	return s.unsafeContains(el)
-}
-
-func (s *Set[E]) unsafeContains(el E) bool {
-	if s.values == nil {
-		s.values = map[E]struct{}{}
-	}
-	_, found := s.values[el]
-	return found
+	return s.unsync.Contains(el)
 }
 
 // Append adds the element to the set if it does not already exist.
@@ -49,41 +48,72 @@ func (s *Set[E]) unsafeContains(el E) bool {
 func (s *Set[E]) Append(el E) {
 	s.mux.Lock()
 	defer s.mux.Unlock()
-	s.unsafeAppend(el)
-}
-
-func (s *Set[E]) unsafeAppend(el E) {
-	if s.unsafeContains(el) {
-		return
-	}
-	s.values[el] = struct{}{}
+	s.unsync.Append(el)
 }
 
 // Values returns an iterator over the elements of the set.
+// It is safe for concurrent use.
 func (s *Set[E]) Values() iter.Seq[E] {
 	return func(yield func(E) bool) {
-		for el := range s.values {
-			if !yield(el) {
-				return
-			}
-		}
+		s.mux.RLock()
+		defer s.mux.RUnlock()
+		s.unsync.Values()(yield)
 	}
 }
 
+// Remove removes the element from the set if it exists.
+// It is safe for concurrent use.
 func (s *Set[E]) Remove(removedEl E) {
 	s.mux.Lock()
 	defer s.mux.Unlock()
-	if !s.unsafeContains(removedEl) {
-		// short circuit
-		return
-	}
-	delete(s.values, removedEl)
+	s.unsync.Remove(removedEl)
+}
+
+// Clear removes all elements from the set.
+// It is safe for concurrent use.
+func (s *Set[E]) Clear() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.unsync.Clear()
+}
+
+// Unsync returns a new [UnsyncSet] containing a copy of the elements of s.
+// s is left unchanged; use [Set.MoveToUnsync] to convert without copying. It is safe for concurrent use.
+func (s *Set[E]) Unsync() *UnsyncSet[E] {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return s.unsync.clone()
+}
+
+// MoveToUnsync moves the elements of s into a new [UnsyncSet] and leaves s empty.
+// The elements are not copied, so the conversion runs in O(1).
+// It is safe for concurrent use, but other goroutines that still use s observe it as empty afterwards.
+func (s *Set[E]) MoveToUnsync() *UnsyncSet[E] {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	ret := &UnsyncSet[E]{values: s.unsync.values}
+	s.unsync = UnsyncSet[E]{values: nil}
+	return ret
 }
 
 // Diff returns a new [Set] containing elements that are in s or other but not in both.
+//
+// Deprecated: Use [Set.SymmetricDifference].
 func (s *Set[E]) Diff(other *Set[E]) *Set[E] {
+	return s.SymmetricDifference(other)
+}
+
+// SymmetricDifference returns a new [Set] containing elements that are in s or other but not in both.
+func (s *Set[E]) SymmetricDifference(other *Set[E]) *Set[E] {
+	ret := NewSet[E]()
+	buildSymmetricDifference(ret, s, other)
+	return ret
+}
+
+// Difference returns a new [Set] containing elements that are in s but not in other.
+func (s *Set[E]) Difference(other *Set[E]) *Set[E] {
 	ret := NewSet[E]()
-	buildDiff(ret, s, other)
+	buildDifference(ret, s, other)
 	return ret
 }
 
@@ -100,3 +130,93 @@ func (s *Set[E]) Union(other *Set[E]) *Set[E] {
 	buildUnion(ret, s, other)
 	return ret
 }
+
+// IsSubset reports whether every element of s is also in other.
+func (s *Set[E]) IsSubset(other SetLike[E]) bool {
+	return IsSubset[E](s, other)
+}
+
+// IsProperSubset reports whether s is a subset of other and other has at least one element that s does not have.
+func (s *Set[E]) IsProperSubset(other SetLike[E]) bool {
+	return IsProperSubset[E](s, other)
+}
+
+// IsSuperset reports whether every element of other is also in s.
+func (s *Set[E]) IsSuperset(other SetLike[E]) bool {
+	return IsSuperset[E](s, other)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+func (s *Set[E]) IsDisjoint(other SetLike[E]) bool {
+	return IsDisjoint[E](s, other)
+}
+
+// Equal reports whether s and other contain the same elements, regardless of their order.
+func (s *Set[E]) Equal(other SetLike[E]) bool {
+	return Equal[E](s, other)
+}
+
+func (s *Set[E]) isSelf(other SetLike[E]) bool {
+	o, ok := unwrapView(other).(*Set[E])
+	return ok && o == s
+}
+
+// UnionWith adds all elements of other to s.
+// It is safe for concurrent use.
+func (s *Set[E]) UnionWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		return
+	}
+	values := detachedValues(other)
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for el := range values {
+		s.unsync.Append(el)
+	}
+}
+
+// IntersectWith removes the elements of s that are not present in other.
+// It is safe for concurrent use.
+func (s *Set[E]) IntersectWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		return
+	}
+	other = detachedSetLike(other)
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.unsync.IntersectWith(other)
+}
+
+// DifferenceWith removes the elements of s that are present in other.
+// It is safe for concurrent use.
+func (s *Set[E]) DifferenceWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		s.Clear()
+		return
+	}
+	values := detachedValues(other)
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for el := range values {
+		s.unsync.Remove(el)
+	}
+}
+
+// SymmetricDifferenceWith updates s to contain the elements that are in s or other but not in both.
+// It is safe for concurrent use.
+func (s *Set[E]) SymmetricDifferenceWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		s.Clear()
+		return
+	}
+	values := detachedValues(other)
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for el := range values {
+		if s.unsync.Contains(el) {
+			s.unsync.Remove(el)
+		} else {
+			s.unsync.Append(el)
+		}
+	}
+}
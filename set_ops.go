@@ -1,72 +1,471 @@
 package coll
 
-import "iter"
+import (
+	"cmp"
+	"iter"
+	"reflect"
+	"slices"
+)
 
+// SetLike is the read-only interface shared by the set types in this package.
+//
+// The package-level set operations such as [Union] return a new set of the same kind as their first operand:
+// an [UnsyncSet] or an [UnsyncOrderedSet] for the unsynchronized sets, a [BitSet] for a BitSet,
+// an [OrderedSet] for the other ordered sets such as [OrderedSet], [PersistentOrderedSet], [SortedSet], [SortedSetFunc]
+// and a [SetView] of an ordered set, and a [Set] otherwise.
+// The results keep the order of an ordered first operand.
+// As an exception, the union or the symmetric difference of a BitSet and a set having an element larger than [MaxBitSetElement]
+// is an [OrderedSet], since a BitSet cannot hold the element.
+//
+// If both operands are [BitSet] values, the operations combine their words directly instead of iterating over the elements.
 type SetLike[E comparable] interface {
 	Len() int
 	Values() iter.Seq[E]
 	Contains(E) bool
 }
 
-type almostSet[E comparable] interface {
+// MutableSetLike is a [SetLike] that can be modified.
+// It is the destination accepted by the ...Into functions, so that set implementations outside of this package
+// can receive the results of the set operations.
+type MutableSetLike[E comparable] interface {
 	SetLike[E]
-	unsafeAppend(E)
+	// Append adds the element to the set if it does not already exist.
+	Append(E)
+	// Remove removes the element from the set if it exists.
+	Remove(E)
+	// Clear removes all elements from the set.
+	Clear()
 }
 
-// Diff returns a new [Set] containing elements that are in xs or ys but not in both.
+var (
+	_ MutableSetLike[int]  = (*Set[int])(nil)
+	_ MutableSetLike[int]  = (*OrderedSet[int])(nil)
+	_ MutableSetLike[int]  = (*UnsyncSet[int])(nil)
+	_ MutableSetLike[int]  = (*UnsyncOrderedSet[int])(nil)
+	_ MutableSetLike[int]  = (*ShardedSet[int])(nil)
+	_ MutableSetLike[int]  = (*CopyOnWriteSet[int])(nil)
+	_ MutableSetLike[int]  = (*SortedSet[int])(nil)
+	_ MutableSetLike[int]  = (*SortedSetFunc[int])(nil)
+	_ MutableSetLike[uint] = (*BitSet)(nil)
+	_ SetLike[int]         = (*PersistentSet[int])(nil)
+	_ SetLike[int]         = (*PersistentOrderedSet[int])(nil)
+)
+
+// isOrdered reports whether the iteration order of xs is meaningful and should be kept by the results derived from it.
+func isOrdered[E comparable](xs SetLike[E]) bool {
+	switch xs := xs.(type) {
+	case *OrderedSet[E], *UnsyncOrderedSet[E], *PersistentOrderedSet[E], interface{ sorted() }:
+		return true
+	case SetView[E]:
+		return xs.set != nil && isOrdered(xs.set)
+	default:
+		// BitSet iterates in ascending order
+		_, ok := any(xs).(*BitSet)
+		return ok
+	}
+}
+
+// newUnionSetLike returns an empty set of the same kind as xs for a result that may have the elements of others.
+// If xs is a BitSet and others have an element that it cannot hold, it returns an [OrderedSet], which keeps the ascending order of xs.
+func newUnionSetLike[E comparable](xs SetLike[E], others ...SetLike[E]) MutableSetLike[E] {
+	ret := newSetLike(xs)
+	if _, ok := any(ret).(*BitSet); !ok {
+		return ret
+	}
+	for _, ys := range others {
+		if !fitsBitSet(ys) {
+			return NewOrderedSet[E]()
+		}
+	}
+	return ret
+}
+
+// fitsBitSet reports whether a BitSet can hold every element of xs, whose element type is uint if it is called for a BitSet.
+func fitsBitSet[E comparable](xs SetLike[E]) bool {
+	if _, ok := any(xs).(*BitSet); ok {
+		return true
+	}
+	for v := range xs.Values() {
+		if el, ok := any(v).(uint); ok && el > MaxBitSetElement {
+			return false
+		}
+	}
+	return true
+}
+
+// newSetLike returns an empty set of the same kind as xs, as described by [SetLike].
+// The unsynchronized variants produce unsynchronized results so that they stay lock-free.
+func newSetLike[E comparable](xs SetLike[E]) MutableSetLike[E] {
+	switch xs.(type) {
+	case *UnsyncOrderedSet[E]:
+		return NewUnsyncOrderedSet[E]()
+	case *UnsyncSet[E]:
+		return NewUnsyncSet[E]()
+	}
+	if _, ok := any(xs).(*BitSet); ok {
+		// E is uint here, so the assertion always succeeds
+		if ret, ok := any(NewBitSet()).(MutableSetLike[E]); ok {
+			return ret
+		}
+	}
+	if isOrdered(xs) {
+		return NewOrderedSet[E]()
+	}
+	return NewSet[E]()
+}
+
+// Diff returns a new set containing elements that are in xs or ys but not in both.
+//
+// Deprecated: Use [SymmetricDifference].
 func Diff[E comparable](xs, ys SetLike[E]) SetLike[E] {
-	ret := NewSet[E]()
-	buildDiff(ret, xs, ys)
+	return SymmetricDifference(xs, ys)
+}
+
+// SymmetricDifference returns a new set containing elements that are in xs or ys but not in both.
+// The result is of the kind that [SetLike] describes for xs.
+// Elements of xs come first in their order, followed by elements of ys in their order.
+func SymmetricDifference[E comparable](xs, ys SetLike[E]) SetLike[E] {
+	if ret, ok := combineIfBitSets(xs, ys, symmetricDifferenceWords); ok {
+		return ret
+	}
+	ret := newUnionSetLike(xs, ys)
+	buildSymmetricDifference(ret, xs, ys)
 	return ret
 }
 
-func buildDiff[E comparable](ret almostSet[E], xs, ys SetLike[E]) {
-	lhs := xs
-	rhs := ys
-	if lhs.Len() < rhs.Len() {
-		lhs = ys
-		rhs = xs
+// SymmetricDifferenceInto adds elements that are in xs or ys but not in both to dst.
+// The elements already in dst are kept. dst must not be xs or ys.
+func SymmetricDifferenceInto[E comparable](dst MutableSetLike[E], xs, ys SetLike[E]) {
+	buildSymmetricDifference(dst, xs, ys)
+}
+
+func buildSymmetricDifference[E comparable](ret MutableSetLike[E], xs, ys SetLike[E]) {
+	buildDifference(ret, xs, ys)
+	buildDifference(ret, ys, xs)
+}
+
+// Difference returns a new set containing elements that are in xs but not in ys.
+// The result is of the kind that [SetLike] describes for xs.
+// The order of the elements in xs is preserved.
+func Difference[E comparable](xs, ys SetLike[E]) SetLike[E] {
+	if ret, ok := combineIfBitSets(xs, ys, differenceWords); ok {
+		return ret
 	}
-	for lv := range lhs.Values() {
-		if !rhs.Contains(lv) {
-			ret.unsafeAppend(lv)
+	ret := newSetLike(xs)
+	buildDifference(ret, xs, ys)
+	return ret
+}
+
+// DifferenceInto adds elements that are in xs but not in ys to dst.
+// The elements already in dst are kept. dst must not be xs or ys.
+func DifferenceInto[E comparable](dst MutableSetLike[E], xs, ys SetLike[E]) {
+	buildDifference(dst, xs, ys)
+}
+
+func buildDifference[E comparable](ret MutableSetLike[E], xs, ys SetLike[E]) {
+	for v := range detachedValues(xs) {
+		if !ys.Contains(v) {
+			ret.Append(v)
 		}
 	}
 }
 
-// Intersect returns a new [Set] containing elements that are present in both xs and ys.
+// Intersect returns a new set containing elements that are present in both xs and ys.
+// The result is of the kind that [SetLike] describes for xs.
+// The order of the elements in xs is preserved.
 func Intersect[E comparable](xs, ys SetLike[E]) SetLike[E] {
-	ret := NewSet[E]()
+	if ret, ok := combineIfBitSets(xs, ys, intersectWords); ok {
+		return ret
+	}
+	ret := newSetLike(xs)
 	buildIntersection(ret, xs, ys)
 	return ret
 }
 
-func buildIntersection[E comparable](ret almostSet[E], xs, ys SetLike[E]) {
+// IntersectInto adds elements that are present in both xs and ys to dst.
+// The elements already in dst are kept. dst must not be xs or ys.
+func IntersectInto[E comparable](dst MutableSetLike[E], xs, ys SetLike[E]) {
+	buildIntersection(dst, xs, ys)
+}
+
+func buildIntersection[E comparable](ret MutableSetLike[E], xs, ys SetLike[E]) {
 	lhs := xs
 	rhs := ys
-	if lhs.Len() < rhs.Len() {
+	// iterate over the smaller set unless the order of xs must be kept
+	if !isOrdered(xs) && lhs.Len() > rhs.Len() {
 		lhs = ys
 		rhs = xs
 	}
-	for lv := range lhs.Values() {
+	for lv := range detachedValues(lhs) {
 		if rhs.Contains(lv) {
-			ret.unsafeAppend(lv)
+			ret.Append(lv)
 		}
 	}
 }
 
-// Union returns a new [Set] containing all elements from both xs and ys.
+// Union returns a new set containing all elements from both xs and ys.
+// The result is of the kind that [SetLike] describes for xs.
+// Elements of xs come first in their order, followed by the rest of ys in their order.
 func Union[E comparable](xs, ys SetLike[E]) SetLike[E] {
-	ret := NewSet[E]()
+	if ret, ok := combineIfBitSets(xs, ys, unionWords); ok {
+		return ret
+	}
+	ret := newUnionSetLike(xs, ys)
 	buildUnion(ret, xs, ys)
 	return ret
 }
 
-func buildUnion[E comparable](ret almostSet[E], xs, ys SetLike[E]) {
-	for v := range xs.Values() {
-		ret.unsafeAppend(v)
+// UnionInto adds all elements from both xs and ys to dst.
+// The elements already in dst are kept. dst must not be xs or ys.
+func UnionInto[E comparable](dst MutableSetLike[E], xs, ys SetLike[E]) {
+	buildUnion(dst, xs, ys)
+}
+
+func buildUnion[E comparable](ret MutableSetLike[E], xs, ys SetLike[E]) {
+	for v := range detachedValues(xs) {
+		ret.Append(v)
+	}
+	for v := range detachedValues(ys) {
+		ret.Append(v)
+	}
+}
+
+// UnionAll returns a new set containing all elements from every set.
+// The result is of the kind that [SetLike] describes for the first set.
+func UnionAll[E comparable](sets ...SetLike[E]) SetLike[E] {
+	if len(sets) == 0 {
+		return NewSet[E]()
+	}
+	ret := newUnionSetLike(sets[0], sets[1:]...)
+	for _, xs := range sets {
+		for v := range xs.Values() {
+			ret.Append(v)
+		}
+	}
+	return ret
+}
+
+// UnionAllInto adds all elements from every set to dst.
+// The elements already in dst are kept. dst must not be one of sets.
+func UnionAllInto[E comparable](dst MutableSetLike[E], sets ...SetLike[E]) {
+	for _, xs := range sets {
+		for v := range detachedValues(xs) {
+			dst.Append(v)
+		}
 	}
-	for v := range ys.Values() {
-		ret.unsafeAppend(v)
+}
+
+// UnionSeq returns a new set containing all elements from every set yielded by sets.
+// The result is of the kind that [SetLike] describes for the first set.
+func UnionSeq[E comparable](sets iter.Seq[SetLike[E]]) SetLike[E] {
+	return UnionAll(slices.Collect(sets)...)
+}
+
+// IntersectAll returns a new set containing elements that are present in every set.
+// The result is of the kind that [SetLike] describes for the first set, and follows its order if it is ordered.
+// It returns an empty set if no sets are given.
+//
+// The intersection starts from the smallest set and narrows it down with the others in ascending order of size,
+// stopping as soon as the result becomes empty.
+func IntersectAll[E comparable](sets ...SetLike[E]) SetLike[E] {
+	if len(sets) == 0 {
+		return NewSet[E]()
+	}
+	ret := newSetLike(sets[0])
+	buildIntersectionAll(ret, sets)
+	return ret
+}
+
+// IntersectAllInto adds elements that are present in every set to dst.
+// The elements already in dst are kept. dst must not be one of sets.
+// See [IntersectAll] for details.
+func IntersectAllInto[E comparable](dst MutableSetLike[E], sets ...SetLike[E]) {
+	buildIntersectionAll(dst, sets)
+}
+
+// IntersectSeq returns a new set containing elements that are present in every set yielded by sets.
+// See [IntersectAll] for details.
+func IntersectSeq[E comparable](sets iter.Seq[SetLike[E]]) SetLike[E] {
+	return IntersectAll(slices.Collect(sets)...)
+}
+
+func buildIntersectionAll[E comparable](ret MutableSetLike[E], sets []SetLike[E]) {
+	if len(sets) == 0 {
+		return
+	}
+	sorted := slices.Clone(sets)
+	slices.SortFunc(sorted, func(a, b SetLike[E]) int { return cmp.Compare(a.Len(), b.Len()) })
+	acc := NewUnsyncSet[E]()
+	acc.UnionWith(sorted[0])
+	for _, xs := range sorted[1:] {
+		if acc.Len() == 0 {
+			return
+		}
+		acc.IntersectWith(xs)
+	}
+	if acc.Len() == 0 {
+		return
+	}
+	if !isOrdered(sets[0]) {
+		for v := range acc.Values() {
+			ret.Append(v)
+		}
+		return
+	}
+	for v := range detachedValues(sets[0]) {
+		if acc.Contains(v) {
+			ret.Append(v)
+		}
+	}
+}
+
+// IsSubset reports whether every element of xs is also in ys.
+func IsSubset[E comparable](xs, ys SetLike[E]) bool {
+	if isSameSet(xs, ys) {
+		return true
+	}
+	if xs.Len() > ys.Len() {
+		return false
+	}
+	return containsAll(ys, xs)
+}
+
+// IsProperSubset reports whether xs is a subset of ys and ys has at least one element that xs does not have.
+func IsProperSubset[E comparable](xs, ys SetLike[E]) bool {
+	if isSameSet(xs, ys) {
+		return false
+	}
+	if xs.Len() >= ys.Len() {
+		return false
+	}
+	return containsAll(ys, xs)
+}
+
+// IsSuperset reports whether every element of ys is also in xs.
+func IsSuperset[E comparable](xs, ys SetLike[E]) bool {
+	return IsSubset(ys, xs)
+}
+
+// IsDisjoint reports whether xs and ys have no elements in common.
+func IsDisjoint[E comparable](xs, ys SetLike[E]) bool {
+	if isSameSet(xs, ys) {
+		return xs.Len() == 0
+	}
+	if bx, by, ok := asBitSets(xs, ys); ok {
+		return compareBitSets(bx, by, func(x, y uint64) bool { return x&y == 0 })
+	}
+	lhs := xs
+	rhs := ys
+	if lhs.Len() > rhs.Len() {
+		lhs = ys
+		rhs = xs
+	}
+	for lv := range detachedValues(lhs) {
+		if rhs.Contains(lv) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether xs and ys contain the same elements, regardless of their order.
+func Equal[E comparable](xs, ys SetLike[E]) bool {
+	if isSameSet(xs, ys) {
+		return true
+	}
+	if xs.Len() != ys.Len() {
+		return false
+	}
+	return containsAll(xs, ys)
+}
+
+// equalOrdered reports whether xs and ys yield the same elements in the same order.
+func equalOrdered[E comparable](xs, ys SetLike[E]) bool {
+	if xs.Len() != ys.Len() {
+		return false
+	}
+	next, stop := iter.Pull(detachedValues(ys))
+	defer stop()
+	for x := range xs.Values() {
+		y, ok := next()
+		if !ok || x != y {
+			return false
+		}
+	}
+	_, ok := next()
+	return !ok
+}
+
+// isSameSet reports whether xs and ys are the same set.
+// The predicates check it first to answer without reading the elements.
+func isSameSet[E comparable](xs, ys SetLike[E]) bool {
+	vx, vy := reflect.ValueOf(unwrapView(xs)), reflect.ValueOf(unwrapView(ys))
+	return vx.Kind() == reflect.Pointer && vy.Kind() == reflect.Pointer && vx.Type() == vy.Type() && vx.Pointer() == vy.Pointer()
+}
+
+// containsAll reports whether xs contains every element of ys.
+func containsAll[E comparable](xs, ys SetLike[E]) bool {
+	if bx, by, ok := asBitSets(xs, ys); ok {
+		return compareBitSets(bx, by, func(x, y uint64) bool { return y&^x == 0 })
+	}
+	for v := range detachedValues(ys) {
+		if !xs.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLockFree reports whether reading xs takes no lock, so that xs can be iterated while another set is locked.
+// Sets of unknown types are assumed to lock.
+func isLockFree[E comparable](xs SetLike[E]) bool {
+	switch xs := xs.(type) {
+	case *UnsyncSet[E], *UnsyncOrderedSet[E], *PersistentSet[E], *PersistentOrderedSet[E], *CopyOnWriteSet[E], *setSnapshot[E]:
+		return true
+	case SetView[E]:
+		return xs.set == nil || isLockFree(xs.set)
+	default:
+		return false
+	}
+}
+
+// detachedValues returns an iterator over the elements of xs that holds no lock of xs while yielding.
+// It iterates over a copy of the elements unless xs is lock-free.
+//
+// The set operations iterate over one operand while they call Contains of the other or Append of the destination.
+// Holding the read lock of the operand meanwhile would deadlock with a waiting writer if the other set is the same set,
+// or with a concurrent call that locks the two sets in the opposite order.
+func detachedValues[E comparable](xs SetLike[E]) iter.Seq[E] {
+	if isLockFree(xs) {
+		return xs.Values()
+	}
+	return slices.Values(slices.Collect(xs.Values()))
+}
+
+// detachedSetLike returns other if it is lock-free, and otherwise a copy of its elements for the callers that need Contains.
+// See [detachedValues].
+func detachedSetLike[E comparable](other SetLike[E]) SetLike[E] {
+	if isLockFree(other) {
+		return other
+	}
+	snapshot := NewUnsyncSet[E]()
+	snapshot.UnionWith(other)
+	return snapshot
+}
+
+// asBitSets returns xs and ys as BitSets if both of them are.
+func asBitSets[E comparable](xs, ys SetLike[E]) (*BitSet, *BitSet, bool) {
+	bx, okx := any(xs).(*BitSet)
+	by, oky := any(ys).(*BitSet)
+	return bx, by, okx && oky
+}
+
+// combineIfBitSets returns a new BitSet combined word by word with op if both xs and ys are BitSets.
+func combineIfBitSets[E comparable](xs, ys SetLike[E], op func(x, y uint64) uint64) (SetLike[E], bool) {
+	bx, by, ok := asBitSets(xs, ys)
+	if !ok {
+		return nil, false
 	}
+	ret, ok := any(combineBitSets(bx, by, op)).(SetLike[E])
+	return ret, ok
 }
@@ -1,8 +1,10 @@
 package coll_test
 
 import (
+	"iter"
 	"reflect"
 	"slices"
+	"sync"
 	"testing"
 
 	"github.com/aereal/coll"
@@ -11,9 +13,27 @@ import (
 func Test_set_ops(t *testing.T) {
 	t.Run("Diff()", func(t *testing.T) {
 		xs := coll.NewSet(1, 2, 3, 4, 5)
-		ys := coll.NewOrderedSet(1, 2, 3)
+		ys := coll.NewOrderedSet(1, 2, 3, 6)
 		got := slices.Sorted(coll.Diff(xs, ys).Values())
-		want := []int{4, 5}
+		want := slices.Sorted(coll.SymmetricDifference(xs, ys).Values())
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+		}
+	})
+	t.Run("SymmetricDifference()", func(t *testing.T) {
+		xs := coll.NewSet(1, 2, 3, 4, 5)
+		ys := coll.NewOrderedSet(1, 2, 3, 6)
+		got := slices.Sorted(coll.SymmetricDifference(xs, ys).Values())
+		want := []int{4, 5, 6}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+		}
+	})
+	t.Run("Difference()", func(t *testing.T) {
+		xs := coll.NewSet(1, 2, 3)
+		ys := coll.NewOrderedSet(1, 2, 4, 5, 6)
+		got := slices.Sorted(coll.Difference(xs, ys).Values())
+		want := []int{3}
 		if !reflect.DeepEqual(got, want) {
 			t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
 		}
@@ -37,3 +57,443 @@ func Test_set_ops(t *testing.T) {
 		}
 	})
 }
+
+func Test_set_predicates(t *testing.T) {
+	self := coll.NewSet(1, 2, 3)
+	emptySelf := coll.NewOrderedSet[int]()
+	testCases := []struct {
+		xs               coll.SetLike[int]
+		ys               coll.SetLike[int]
+		name             string
+		wantSubset       bool
+		wantProperSubset bool
+		wantSuperset     bool
+		wantDisjoint     bool
+		wantEqual        bool
+	}{
+		{
+			name:             "empty vs empty",
+			xs:               coll.NewSet[int](),
+			ys:               coll.NewOrderedSet[int](),
+			wantSubset:       true,
+			wantProperSubset: false,
+			wantSuperset:     true,
+			wantDisjoint:     true,
+			wantEqual:        true,
+		},
+		{
+			name:             "same elements in different order",
+			xs:               coll.NewSet(1, 2, 3),
+			ys:               coll.NewOrderedSet(3, 2, 1),
+			wantSubset:       true,
+			wantProperSubset: false,
+			wantSuperset:     true,
+			wantDisjoint:     false,
+			wantEqual:        true,
+		},
+		{
+			name:             "xs < ys",
+			xs:               coll.NewSet(1, 2),
+			ys:               coll.NewOrderedSet(1, 2, 3),
+			wantSubset:       true,
+			wantProperSubset: true,
+			wantSuperset:     false,
+			wantDisjoint:     false,
+			wantEqual:        false,
+		},
+		{
+			name:             "xs > ys",
+			xs:               coll.NewSet(1, 2, 3),
+			ys:               coll.NewOrderedSet(2),
+			wantSubset:       false,
+			wantProperSubset: false,
+			wantSuperset:     true,
+			wantDisjoint:     false,
+			wantEqual:        false,
+		},
+		{
+			name:             "overlapping",
+			xs:               coll.NewSet(1, 2),
+			ys:               coll.NewOrderedSet(2, 3),
+			wantSubset:       false,
+			wantProperSubset: false,
+			wantSuperset:     false,
+			wantDisjoint:     false,
+			wantEqual:        false,
+		},
+		{
+			name:             "disjoint",
+			xs:               coll.NewSet(1, 2),
+			ys:               coll.NewOrderedSet(3, 4, 5),
+			wantSubset:       false,
+			wantProperSubset: false,
+			wantSuperset:     false,
+			wantDisjoint:     true,
+			wantEqual:        false,
+		},
+		{
+			name:             "same set",
+			xs:               self,
+			ys:               self,
+			wantSubset:       true,
+			wantProperSubset: false,
+			wantSuperset:     true,
+			wantDisjoint:     false,
+			wantEqual:        true,
+		},
+		{
+			name:             "same empty set",
+			xs:               emptySelf,
+			ys:               emptySelf,
+			wantSubset:       true,
+			wantProperSubset: false,
+			wantSuperset:     true,
+			wantDisjoint:     true,
+			wantEqual:        true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := coll.IsSubset(tc.xs, tc.ys); got != tc.wantSubset {
+				t.Errorf("IsSubset() = %v, want %v", got, tc.wantSubset)
+			}
+			if got := coll.IsProperSubset(tc.xs, tc.ys); got != tc.wantProperSubset {
+				t.Errorf("IsProperSubset() = %v, want %v", got, tc.wantProperSubset)
+			}
+			if got := coll.IsSuperset(tc.xs, tc.ys); got != tc.wantSuperset {
+				t.Errorf("IsSuperset() = %v, want %v", got, tc.wantSuperset)
+			}
+			if got := coll.IsDisjoint(tc.xs, tc.ys); got != tc.wantDisjoint {
+				t.Errorf("IsDisjoint() = %v, want %v", got, tc.wantDisjoint)
+			}
+			if got := coll.Equal(tc.xs, tc.ys); got != tc.wantEqual {
+				t.Errorf("Equal() = %v, want %v", got, tc.wantEqual)
+			}
+		})
+	}
+}
+
+func Test_set_predicates_sameSetWithWriter(t *testing.T) {
+	s := coll.NewSet[int]()
+	for i := range 100 {
+		s.Append(i)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 1000 {
+			s.Append(100 + i)
+		}
+	}()
+	// a writer waiting for the lock blocks a recursive read lock, so comparing a set with itself must not read-lock it twice
+	for range 1000 {
+		if !coll.Equal[int](s, s) || !coll.IsSubset[int](s, s) || coll.IsDisjoint[int](s, s) {
+			t.Fatal("a set is not equal to itself")
+		}
+	}
+	<-done
+}
+
+func Test_set_ops_concurrent(t *testing.T) {
+	sets := map[string]func() coll.MutableSetLike[int]{
+		"Set":        func() coll.MutableSetLike[int] { return coll.NewSet[int]() },
+		"OrderedSet": func() coll.MutableSetLike[int] { return coll.NewOrderedSet[int]() },
+		"SortedSet":  func() coll.MutableSetLike[int] { return coll.NewSortedSet[int]() },
+	}
+	ops := map[string]func(xs, ys coll.SetLike[int]){
+		"Union":               func(xs, ys coll.SetLike[int]) { coll.Union(xs, ys) },
+		"Intersect":           func(xs, ys coll.SetLike[int]) { coll.Intersect(xs, ys) },
+		"Difference":          func(xs, ys coll.SetLike[int]) { coll.Difference(xs, ys) },
+		"SymmetricDifference": func(xs, ys coll.SetLike[int]) { coll.SymmetricDifference(xs, ys) },
+		"IsSubset":            func(xs, ys coll.SetLike[int]) { coll.IsSubset(xs, ys) },
+		"IsDisjoint":          func(xs, ys coll.SetLike[int]) { coll.IsDisjoint(xs, ys) },
+		"Equal":               func(xs, ys coll.SetLike[int]) { coll.Equal(xs, ys) },
+	}
+	for setName, newSet := range sets {
+		for opName, op := range ops {
+			t.Run(setName+"/"+opName, func(t *testing.T) {
+				a, b := newSet(), newSet()
+				for i := range 100 {
+					a.Append(i)
+					b.Append(i + 50)
+				}
+				// a waiting writer blocks new readers, so an operation must not read-lock a set while it holds a read lock of either set
+				stop := make(chan struct{})
+				var writer sync.WaitGroup
+				writer.Add(1)
+				go func() {
+					defer writer.Done()
+					for i := 0; ; i++ {
+						select {
+						case <-stop:
+							return
+						default:
+						}
+						a.Append(100 + i%100)
+						b.Remove(i % 100)
+					}
+				}()
+				var wg sync.WaitGroup
+				for _, pair := range [][2]coll.SetLike[int]{{a, a}, {a, b}, {b, a}} {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						for range 100 {
+							op(pair[0], pair[1])
+						}
+					}()
+				}
+				wg.Wait()
+				close(stop)
+				writer.Wait()
+			})
+		}
+	}
+}
+
+func Test_nary_set_ops(t *testing.T) {
+	testCases := []struct {
+		name          string
+		sets          []coll.SetLike[int]
+		wantUnion     []int
+		wantIntersect []int
+	}{
+		{
+			name:          "no sets",
+			sets:          nil,
+			wantUnion:     nil,
+			wantIntersect: nil,
+		},
+		{
+			name:          "single set",
+			sets:          []coll.SetLike[int]{coll.NewSet(1, 2)},
+			wantUnion:     []int{1, 2},
+			wantIntersect: []int{1, 2},
+		},
+		{
+			name: "many sets",
+			sets: []coll.SetLike[int]{
+				coll.NewSet(1, 2, 3, 4, 5),
+				coll.NewOrderedSet(2, 3, 4, 6),
+				coll.NewSet(3, 4, 7),
+			},
+			wantUnion:     []int{1, 2, 3, 4, 5, 6, 7},
+			wantIntersect: []int{3, 4},
+		},
+		{
+			name: "empty intersection",
+			sets: []coll.SetLike[int]{
+				coll.NewSet(1, 2),
+				coll.NewSet(3, 4),
+				coll.NewOrderedSet(1, 2, 3, 4),
+			},
+			wantUnion:     []int{1, 2, 3, 4},
+			wantIntersect: nil,
+		},
+		{
+			name: "including empty set",
+			sets: []coll.SetLike[int]{
+				coll.NewSet(1, 2),
+				coll.NewSet[int](),
+			},
+			wantUnion:     []int{1, 2},
+			wantIntersect: nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := slices.Sorted(coll.UnionAll(tc.sets...).Values()); !reflect.DeepEqual(got, tc.wantUnion) {
+				t.Errorf("UnionAll():\n\twant: %#v\n\t got: %#v", tc.wantUnion, got)
+			}
+			if got := slices.Sorted(coll.UnionSeq(slices.Values(tc.sets)).Values()); !reflect.DeepEqual(got, tc.wantUnion) {
+				t.Errorf("UnionSeq():\n\twant: %#v\n\t got: %#v", tc.wantUnion, got)
+			}
+			if got := slices.Sorted(coll.IntersectAll(tc.sets...).Values()); !reflect.DeepEqual(got, tc.wantIntersect) {
+				t.Errorf("IntersectAll():\n\twant: %#v\n\t got: %#v", tc.wantIntersect, got)
+			}
+			if got := slices.Sorted(coll.IntersectSeq(slices.Values(tc.sets)).Values()); !reflect.DeepEqual(got, tc.wantIntersect) {
+				t.Errorf("IntersectSeq():\n\twant: %#v\n\t got: %#v", tc.wantIntersect, got)
+			}
+		})
+	}
+}
+
+func Test_set_ops_keepOrder(t *testing.T) {
+	testCases := []struct {
+		op   func(xs, ys coll.SetLike[int]) coll.SetLike[int]
+		name string
+		want []int
+	}{
+		{
+			name: "SymmetricDifference",
+			op:   coll.SymmetricDifference[int],
+			want: []int{5, 1, 6},
+		},
+		{
+			name: "Difference",
+			op:   coll.Difference[int],
+			want: []int{5, 1},
+		},
+		{
+			name: "Intersect",
+			op:   coll.Intersect[int],
+			want: []int{3, 2},
+		},
+		{
+			name: "Union",
+			op:   coll.Union[int],
+			want: []int{5, 3, 1, 2, 6},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.op(coll.NewOrderedSet(5, 3, 1, 2), coll.NewSet(6, 2, 3))
+			if _, ok := got.(*coll.OrderedSet[int]); !ok {
+				t.Fatalf("result is not an OrderedSet: %T", got)
+			}
+			if gotSlice := slices.Collect(got.Values()); !reflect.DeepEqual(tc.want, gotSlice) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, gotSlice)
+			}
+		})
+	}
+	t.Run("UnionAll", func(t *testing.T) {
+		got := coll.UnionAll[int](coll.NewOrderedSet(3, 1), coll.NewSet(2), coll.NewOrderedSet(4, 1))
+		if _, ok := got.(*coll.OrderedSet[int]); !ok {
+			t.Fatalf("result is not an OrderedSet: %T", got)
+		}
+		want := []int{3, 1, 2, 4}
+		if gotSlice := slices.Collect(got.Values()); !reflect.DeepEqual(want, gotSlice) {
+			t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, gotSlice)
+		}
+	})
+	t.Run("IntersectAll", func(t *testing.T) {
+		got := coll.IntersectAll[int](coll.NewOrderedSet(5, 4, 3, 2, 1), coll.NewSet(1, 2, 4, 6), coll.NewSet(4, 1))
+		if _, ok := got.(*coll.OrderedSet[int]); !ok {
+			t.Fatalf("result is not an OrderedSet: %T", got)
+		}
+		want := []int{4, 1}
+		if gotSlice := slices.Collect(got.Values()); !reflect.DeepEqual(want, gotSlice) {
+			t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, gotSlice)
+		}
+	})
+	t.Run("plain Set", func(t *testing.T) {
+		got := coll.Union[int](coll.NewSet(1), coll.NewOrderedSet(2))
+		if _, ok := got.(*coll.Set[int]); !ok {
+			t.Fatalf("result is not a Set: %T", got)
+		}
+	})
+}
+
+func Test_set_ops_into(t *testing.T) {
+	testCases := []struct {
+		op   func(dst coll.MutableSetLike[int], xs, ys coll.SetLike[int])
+		name string
+		want []int
+	}{
+		{
+			name: "SymmetricDifferenceInto",
+			op:   coll.SymmetricDifferenceInto[int],
+			want: []int{0, 1, 4},
+		},
+		{
+			name: "DifferenceInto",
+			op:   coll.DifferenceInto[int],
+			want: []int{0, 1},
+		},
+		{
+			name: "IntersectInto",
+			op:   coll.IntersectInto[int],
+			want: []int{0, 2, 3},
+		},
+		{
+			name: "UnionInto",
+			op:   coll.UnionInto[int],
+			want: []int{0, 1, 2, 3, 4},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := coll.NewOrderedSet(0)
+			tc.op(dst, coll.NewSet(1, 2, 3), coll.NewOrderedSet(2, 3, 4))
+			if got := slices.Sorted(dst.Values()); !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, got)
+			}
+		})
+	}
+}
+
+// sliceSet is a minimal MutableSetLike implemented outside of the package.
+type sliceSet[E comparable] struct {
+	els []E
+}
+
+var _ coll.MutableSetLike[int] = (*sliceSet[int])(nil)
+
+func (s *sliceSet[E]) Len() int            { return len(s.els) }
+func (s *sliceSet[E]) Values() iter.Seq[E] { return slices.Values(s.els) }
+func (s *sliceSet[E]) Contains(el E) bool  { return slices.Contains(s.els, el) }
+func (s *sliceSet[E]) Remove(el E) {
+	s.els = slices.DeleteFunc(s.els, func(e E) bool { return e == el })
+}
+func (s *sliceSet[E]) Clear() { s.els = nil }
+func (s *sliceSet[E]) Append(el E) {
+	if !s.Contains(el) {
+		s.els = append(s.els, el)
+	}
+}
+
+func Test_set_ops_thirdPartyDestination(t *testing.T) {
+	testCases := []struct {
+		op   func(dst coll.MutableSetLike[int])
+		name string
+		want []int
+	}{
+		{
+			name: "SymmetricDifferenceInto",
+			op: func(dst coll.MutableSetLike[int]) {
+				coll.SymmetricDifferenceInto[int](dst, coll.NewSet(1, 2), coll.NewSet(2, 3))
+			},
+			want: []int{1, 3},
+		},
+		{
+			name: "DifferenceInto",
+			op: func(dst coll.MutableSetLike[int]) {
+				coll.DifferenceInto[int](dst, coll.NewSet(1, 2), coll.NewSet(2, 3))
+			},
+			want: []int{1},
+		},
+		{
+			name: "IntersectInto",
+			op:   func(dst coll.MutableSetLike[int]) { coll.IntersectInto[int](dst, coll.NewSet(1, 2), coll.NewSet(2, 3)) },
+			want: []int{2},
+		},
+		{
+			name: "UnionInto",
+			op:   func(dst coll.MutableSetLike[int]) { coll.UnionInto[int](dst, coll.NewSet(1, 2), coll.NewSet(2, 3)) },
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "UnionAllInto",
+			op: func(dst coll.MutableSetLike[int]) {
+				coll.UnionAllInto[int](dst, coll.NewSet(1), coll.NewSet(2), &sliceSet[int]{els: []int{3}})
+			},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "IntersectAllInto",
+			op: func(dst coll.MutableSetLike[int]) {
+				coll.IntersectAllInto[int](dst, coll.NewSet(1, 2, 3), coll.NewSet(2, 3), &sliceSet[int]{els: []int{3, 2}})
+			},
+			want: []int{2, 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := &sliceSet[int]{}
+			tc.op(dst)
+			if got := slices.Sorted(dst.Values()); !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, got)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package coll_test
 import (
 	"reflect"
 	"slices"
+	"sync"
 	"testing"
 
 	"github.com/aereal/coll"
@@ -53,7 +54,7 @@ func TestSet(t *testing.T) {
 	}
 }
 
-func TestSet_Diff(t *testing.T) {
+func TestSet_SymmetricDifference(t *testing.T) {
 	testCases := []struct {
 		lhs  *coll.Set[string]
 		rhs  *coll.Set[string]
@@ -84,10 +85,70 @@ func TestSet_Diff(t *testing.T) {
 			rhs:  coll.NewSet("a", "b", "c"),
 			want: coll.NewSet("b", "c"),
 		},
+		{
+			name: "both have their own elements",
+			lhs:  coll.NewSet("a", "b"),
+			rhs:  coll.NewSet("b", "c", "d"),
+			want: coll.NewSet("a", "c", "d"),
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			got := tc.lhs.Diff(tc.rhs)
+			got := tc.lhs.SymmetricDifference(tc.rhs)
+			gotSlice := slices.Sorted(got.Values())
+			wantSlice := slices.Sorted(tc.want.Values())
+			if !reflect.DeepEqual(wantSlice, gotSlice) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", wantSlice, gotSlice)
+			}
+		})
+	}
+}
+
+func TestSet_Diff(t *testing.T) {
+	lhs := coll.NewSet("a", "b")
+	rhs := coll.NewSet("b", "c", "d")
+	got := slices.Sorted(lhs.Diff(rhs).Values())
+	want := slices.Sorted(lhs.SymmetricDifference(rhs).Values())
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+func TestSet_Difference(t *testing.T) {
+	testCases := []struct {
+		lhs  *coll.Set[string]
+		rhs  *coll.Set[string]
+		want *coll.Set[string]
+		name string
+	}{
+		{
+			name: "empty vs empty",
+			lhs:  coll.NewSet[string](),
+			rhs:  coll.NewSet[string](),
+			want: coll.NewSet[string](),
+		},
+		{
+			name: "lhs == rhs",
+			lhs:  coll.NewSet("a", "b", "c"),
+			rhs:  coll.NewSet("a", "b", "c"),
+			want: coll.NewSet[string](),
+		},
+		{
+			name: "lhs > rhs",
+			lhs:  coll.NewSet("a", "b", "c"),
+			rhs:  coll.NewSet("b"),
+			want: coll.NewSet("a", "c"),
+		},
+		{
+			name: "lhs < rhs",
+			lhs:  coll.NewSet("a", "d"),
+			rhs:  coll.NewSet("a", "b", "c"),
+			want: coll.NewSet("d"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.lhs.Difference(tc.rhs)
 			gotSlice := slices.Sorted(got.Values())
 			wantSlice := slices.Sorted(tc.want.Values())
 			if !reflect.DeepEqual(wantSlice, gotSlice) {
@@ -191,3 +252,190 @@ func TestSet_empty(t *testing.T) {
 		t.Errorf("Contains(42) reports true unexpectedly")
 	}
 }
+
+func TestSet_concurrentAccess(t *testing.T) {
+	nums := coll.NewSet[int]()
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range 100 {
+				nums.Append(i*100 + n)
+				if n%3 == 0 {
+					nums.Remove(i*100 + n)
+				}
+			}
+		}()
+	}
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_ = nums.Len()
+				_ = nums.Contains(42)
+				for n := range nums.Values() {
+					_ = n
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if got := nums.Len(); got != 4*66 {
+		t.Errorf("Len() returns unexpected value: %d", got)
+	}
+}
+
+func TestSet_predicates(t *testing.T) {
+	xs := coll.NewSet("a", "b")
+	if !xs.IsSubset(coll.NewOrderedSet("b", "a", "c")) {
+		t.Error("IsSubset() reports false unexpectedly")
+	}
+	if !xs.IsProperSubset(coll.NewSet("a", "b", "c")) {
+		t.Error("IsProperSubset() reports false unexpectedly")
+	}
+	if !xs.IsSuperset(coll.NewSet("a")) {
+		t.Error("IsSuperset() reports false unexpectedly")
+	}
+	if !xs.IsDisjoint(coll.NewSet("c")) {
+		t.Error("IsDisjoint() reports false unexpectedly")
+	}
+	if !xs.Equal(coll.NewOrderedSet("b", "a")) {
+		t.Error("Equal() reports false unexpectedly")
+	}
+	if xs.Equal(coll.NewSet("a", "c")) {
+		t.Error("Equal() reports true unexpectedly")
+	}
+}
+
+func TestSet_inPlaceOperations(t *testing.T) {
+	testCases := []struct {
+		apply func(s *coll.Set[string], other coll.SetLike[string])
+		name  string
+		want  []string
+	}{
+		{
+			name:  "UnionWith",
+			apply: (*coll.Set[string]).UnionWith,
+			want:  []string{"a", "b", "c", "d"},
+		},
+		{
+			name:  "IntersectWith",
+			apply: (*coll.Set[string]).IntersectWith,
+			want:  []string{"b", "c"},
+		},
+		{
+			name:  "DifferenceWith",
+			apply: (*coll.Set[string]).DifferenceWith,
+			want:  []string{"a"},
+		},
+		{
+			name:  "SymmetricDifferenceWith",
+			apply: (*coll.Set[string]).SymmetricDifferenceWith,
+			want:  []string{"a", "d"},
+		},
+	}
+	// the locked sets are copied before s is locked, while the lock-free ones are read directly
+	others := map[string]func() coll.SetLike[string]{
+		"OrderedSet":           func() coll.SetLike[string] { return coll.NewOrderedSet("b", "c", "d") },
+		"UnsyncOrderedSet":     func() coll.SetLike[string] { return coll.NewUnsyncOrderedSet("b", "c", "d") },
+		"PersistentOrderedSet": func() coll.SetLike[string] { return coll.NewPersistentOrderedSet("b", "c", "d") },
+	}
+	for _, tc := range testCases {
+		for otherName, newOther := range others {
+			t.Run(tc.name+"/"+otherName, func(t *testing.T) {
+				s := coll.NewSet("c", "a", "b")
+				tc.apply(s, newOther())
+				got := slices.Sorted(s.Values())
+				if !reflect.DeepEqual(tc.want, got) {
+					t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, got)
+				}
+			})
+		}
+	}
+}
+
+func TestSet_inPlaceOperations_self(t *testing.T) {
+	testCases := []struct {
+		apply func(s *coll.Set[string], other coll.SetLike[string])
+		name  string
+		want  []string
+	}{
+		{
+			name:  "UnionWith",
+			apply: (*coll.Set[string]).UnionWith,
+			want:  []string{"a", "b"},
+		},
+		{
+			name:  "IntersectWith",
+			apply: (*coll.Set[string]).IntersectWith,
+			want:  []string{"a", "b"},
+		},
+		{
+			name:  "DifferenceWith",
+			apply: (*coll.Set[string]).DifferenceWith,
+			want:  nil,
+		},
+		{
+			name:  "SymmetricDifferenceWith",
+			apply: (*coll.Set[string]).SymmetricDifferenceWith,
+			want:  nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := coll.NewSet("a", "b")
+			tc.apply(s, s)
+			got := slices.Sorted(s.Values())
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSet_inPlaceOperations_concurrent(t *testing.T) {
+	ops := map[string]func(s *coll.Set[int], other coll.SetLike[int]){
+		"UnionWith":               (*coll.Set[int]).UnionWith,
+		"IntersectWith":           (*coll.Set[int]).IntersectWith,
+		"DifferenceWith":          (*coll.Set[int]).DifferenceWith,
+		"SymmetricDifferenceWith": (*coll.Set[int]).SymmetricDifferenceWith,
+	}
+	for name, apply := range ops {
+		t.Run(name, func(t *testing.T) {
+			a, b := coll.NewSet[int](), coll.NewSet[int]()
+			for i := range 1000 {
+				a.Append(i)
+				b.Append(i + 500)
+			}
+			// the two calls lock a and b in opposite orders if the write lock is held while reading other
+			var wg sync.WaitGroup
+			for _, pair := range [][2]*coll.Set[int]{{a, b}, {b, a}} {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range 100 {
+						apply(pair[0], pair[1])
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestSet_Clear(t *testing.T) {
+	strSet := coll.NewSet("a", "b")
+	strSet.Clear()
+	if gotLen := strSet.Len(); gotLen != 0 {
+		t.Errorf("Len() returns unexpected value: %d", gotLen)
+	}
+	if strSet.Contains("a") {
+		t.Error("the set says it DOES contain 'a'")
+	}
+	strSet.Append("c")
+	if got, want := slices.Collect(strSet.Values()), []string{"c"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
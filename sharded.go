@@ -0,0 +1,29 @@
+package coll
+
+import (
+	"hash/maphash"
+	"math/bits"
+	"runtime"
+)
+
+// shardCount returns the number of shards to use for the requested number n.
+// The result is rounded up to a power of two so that a shard can be picked by masking the hash;
+// n <= 0 selects a default that scales with GOMAXPROCS.
+func shardCount(n int) int {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0) * 4
+	}
+	return 1 << bits.Len(uint(n-1))
+}
+
+// shardIndex returns the index of the shard that key belongs to among n shards, where n is a power of two.
+func shardIndex[K comparable](seed maphash.Seed, key K, n int) int {
+	return int(maphash.Comparable(seed, key) & uint64(n-1))
+}
+
+// cacheLinePad separates neighboring shards in memory so that goroutines locking different shards do not falsely share a cache line.
+// It spans 128 bytes, the largest cache line and adjacent-line prefetch unit of common processors,
+// so that the shards are kept apart regardless of the sizes of their fields.
+type cacheLinePad struct {
+	_ [128]byte
+}
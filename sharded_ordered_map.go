@@ -0,0 +1,274 @@
+package coll
+
+import (
+	"container/heap"
+	"hash/maphash"
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// NewShardedOrderedMap returns a new [ShardedOrderedMap] with the given number of shards.
+// The number is rounded up to a power of two, and a number of zero or less selects a default that scales with GOMAXPROCS.
+func NewShardedOrderedMap[K comparable, V any](shards int) *ShardedOrderedMap[K, V] {
+	m := &ShardedOrderedMap[K, V]{
+		shards: nil,
+		seq:    atomic.Uint64{},
+		seed:   maphash.Seed{},
+		once:   sync.Once{},
+	}
+	m.once.Do(func() { m.init(shards) })
+	return m
+}
+
+// ShardedOrderedMap represents a map that preserves insertion order of keys and is split into independently locked shards.
+// It is safe for concurrent use, and unlike [OrderedMap], goroutines that touch keys in different shards do not contend for the same lock.
+// The zero value is an empty map with the default number of shards.
+//
+// Keys are assigned to shards by [maphash.Comparable], and every inserted key is stamped with a sequence number shared by all shards.
+// Operations on a single key lock only its shard, whereas Len and the iterators read-lock every shard
+// and merge the shards by the sequence numbers, so they observe a consistent state of the whole map in insertion order.
+// Iterators hold the read locks for the duration of the iteration, so the map must not be modified from within the loop body.
+type ShardedOrderedMap[K comparable, V any] struct {
+	shards []mapShard[K, V]
+	seq    atomic.Uint64
+	seed   maphash.Seed
+	once   sync.Once
+}
+
+type mapShard[K comparable, V any] struct {
+	entries UnsyncOrderedMap[K, sequencedValue[V]]
+	mux     sync.RWMutex
+	_       cacheLinePad
+}
+
+func (m *ShardedOrderedMap[K, V]) init(shards int) {
+	m.shards = make([]mapShard[K, V], shardCount(shards))
+	m.seed = maphash.MakeSeed()
+}
+
+func (m *ShardedOrderedMap[K, V]) shardFor(key K) *mapShard[K, V] {
+	m.once.Do(func() { m.init(0) })
+	return &m.shards[shardIndex(m.seed, key, len(m.shards))]
+}
+
+// rlockAll read-locks every shard in index order and returns the function that releases them.
+func (m *ShardedOrderedMap[K, V]) rlockAll() func() {
+	m.once.Do(func() { m.init(0) })
+	for i := range m.shards {
+		m.shards[i].mux.RLock()
+	}
+	return func() {
+		for i := range m.shards {
+			m.shards[i].mux.RUnlock()
+		}
+	}
+}
+
+// lockAll write-locks every shard in index order and returns the function that releases them.
+func (m *ShardedOrderedMap[K, V]) lockAll() func() {
+	m.once.Do(func() { m.init(0) })
+	for i := range m.shards {
+		m.shards[i].mux.Lock()
+	}
+	return func() {
+		for i := range m.shards {
+			m.shards[i].mux.Unlock()
+		}
+	}
+}
+
+// Len returns the number of entries in the map.
+// It is safe for concurrent use.
+func (m *ShardedOrderedMap[K, V]) Len() int {
+	defer m.rlockAll()()
+	n := 0
+	for i := range m.shards {
+		n += m.shards[i].entries.Len()
+	}
+	return n
+}
+
+// Get retrieves the value associated with the given key.
+// The second return value indicates whether the key was found.
+// It is safe for concurrent use.
+func (m *ShardedOrderedMap[K, V]) Get(key K) (V, bool) {
+	shard := m.shardFor(key)
+	shard.mux.RLock()
+	defer shard.mux.RUnlock()
+	sv, found := shard.entries.Get(key)
+	return sv.value, found
+}
+
+// Put inserts the key-value pair into the map if the key does not already exist.
+// The insertion order of keys is preserved. It is safe for concurrent use.
+func (m *ShardedOrderedMap[K, V]) Put(key K, value V) {
+	shard := m.shardFor(key)
+	shard.mux.Lock()
+	defer shard.mux.Unlock()
+	if _, found := shard.entries.Get(key); found {
+		return
+	}
+	shard.entries.Set(key, sequencedValue[V]{value: value, seq: m.seq.Add(1)})
+}
+
+// Set associates the value with the key.
+// If the key already exists, its value is overwritten and the key keeps its position;
+// otherwise the key is appended. It is safe for concurrent use.
+func (m *ShardedOrderedMap[K, V]) Set(key K, value V) {
+	shard := m.shardFor(key)
+	shard.mux.Lock()
+	defer shard.mux.Unlock()
+	m.unsafeSet(shard, key, func(V, bool) V { return value })
+}
+
+// Update updates the value associated with the key using the provided function.
+// The updater function receives the current value (or zero value if not found) and a boolean indicating existence.
+// If the key already exists, it keeps its position; otherwise the key is appended.
+// It is safe for concurrent use.
+func (m *ShardedOrderedMap[K, V]) Update(key K, update func(prev V, alreadyExist bool) V) {
+	shard := m.shardFor(key)
+	shard.mux.Lock()
+	defer shard.mux.Unlock()
+	m.unsafeSet(shard, key, update)
+}
+
+func (m *ShardedOrderedMap[K, V]) unsafeSet(shard *mapShard[K, V], key K, update func(prev V, alreadyExist bool) V) {
+	// the sequence number is taken while holding the shard lock, so the entries in a shard are always sorted by it
+	shard.entries.Update(key, func(prev sequencedValue[V], alreadyExist bool) sequencedValue[V] {
+		if !alreadyExist {
+			prev.seq = m.seq.Add(1)
+		}
+		prev.value = update(prev.value, alreadyExist)
+		return prev
+	})
+}
+
+// Delete removes the key from the map and returns the value that was associated with it.
+// The second return value indicates whether the key was found.
+// The order of the remaining keys is preserved. It is safe for concurrent use.
+func (m *ShardedOrderedMap[K, V]) Delete(key K) (V, bool) {
+	shard := m.shardFor(key)
+	shard.mux.Lock()
+	defer shard.mux.Unlock()
+	sv, found := shard.entries.Delete(key)
+	return sv.value, found
+}
+
+// DeleteFunc removes every entry for which del returns true.
+// del is called in insertion order while every shard is locked.
+// The order of the remaining keys is preserved. It is safe for concurrent use.
+func (m *ShardedOrderedMap[K, V]) DeleteFunc(del func(key K, value V) bool) {
+	defer m.lockAll()()
+	for e := range m.unsafeAll() {
+		if del(e.value.key, e.value.value.value) {
+			m.shards[shardIndex(m.seed, e.value.key, len(m.shards))].entries.deleteElement(e)
+		}
+	}
+}
+
+// Clear removes all entries from the map.
+// It is safe for concurrent use.
+func (m *ShardedOrderedMap[K, V]) Clear() {
+	defer m.lockAll()()
+	for i := range m.shards {
+		m.shards[i].entries.Clear()
+	}
+}
+
+// Keys returns an iterator over the keys in insertion order.
+// It is safe for concurrent use.
+func (m *ShardedOrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		defer m.rlockAll()()
+		for e := range m.unsafeAll() {
+			if !yield(e.value.key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values in insertion order of their corresponding keys.
+// It is safe for concurrent use.
+func (m *ShardedOrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		defer m.rlockAll()()
+		for e := range m.unsafeAll() {
+			if !yield(e.value.value.value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over key-value pairs in insertion order.
+// It is safe for concurrent use.
+func (m *ShardedOrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		defer m.rlockAll()()
+		for e := range m.unsafeAll() {
+			if !yield(e.value.key, e.value.value.value) {
+				return
+			}
+		}
+	}
+}
+
+// unsafeAll returns an iterator over the entries of every shard merged in insertion order.
+// The caller must hold the locks of all shards. Like [linkedList.all], the yielded entry may be removed within the loop.
+func (m *ShardedOrderedMap[K, V]) unsafeAll() iter.Seq[*listElement[orderedMapEntry[K, sequencedValue[V]]]] {
+	return func(yield func(*listElement[orderedMapEntry[K, sequencedValue[V]]]) bool) {
+		cursors := make(shardCursors[K, V], 0, len(m.shards))
+		for i := range m.shards {
+			list := &m.shards[i].entries.entries
+			if front := list.front(); front != nil {
+				cursors = append(cursors, shardCursor[K, V]{list: list, elem: front})
+			}
+		}
+		heap.Init(&cursors)
+		for len(cursors) > 0 {
+			e := cursors[0].elem
+			if next := cursors[0].list.nextOf(e); next != nil {
+				cursors[0].elem = next
+				heap.Fix(&cursors, 0)
+			} else {
+				heap.Pop(&cursors)
+			}
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// shardCursor points to the next entry to be merged from a shard.
+type shardCursor[K comparable, V any] struct {
+	list *linkedList[orderedMapEntry[K, sequencedValue[V]]]
+	elem *listElement[orderedMapEntry[K, sequencedValue[V]]]
+}
+
+// shardCursors is a min-heap of cursors ordered by the sequence number of the entries they point to.
+type shardCursors[K comparable, V any] []shardCursor[K, V]
+
+var _ heap.Interface = (*shardCursors[int, int])(nil)
+
+func (h *shardCursors[K, V]) Len() int { return len(*h) }
+
+func (h *shardCursors[K, V]) Less(i, j int) bool {
+	return (*h)[i].elem.value.value.seq < (*h)[j].elem.value.value.seq
+}
+
+func (h *shardCursors[K, V]) Swap(i, j int) { (*h)[i], (*h)[j] = (*h)[j], (*h)[i] }
+
+func (h *shardCursors[K, V]) Push(x any) {
+	c, _ := x.(shardCursor[K, V])
+	*h = append(*h, c)
+}
+
+func (h *shardCursors[K, V]) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
@@ -0,0 +1,153 @@
+package coll_test
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func TestShardedOrderedMap(t *testing.T) {
+	m := coll.NewShardedOrderedMap[string, int](4)
+	m.Put("b", 1)
+	m.Put("a", 2)
+	m.Put("b", 100) // Put does not overwrite
+	m.Set("c", 3)
+	m.Set("a", 20) // Set overwrites and keeps the position
+	m.Update("b", func(prev int, _ bool) int { return prev * 10 })
+	m.Update("d", func(prev int, found bool) int {
+		if found {
+			t.Error("Update(d) reports the key exists")
+		}
+		return prev + 4
+	})
+	if got, found := m.Get("b"); !found || got != 10 {
+		t.Errorf("Get(b): got (%v, %v), want (10, true)", got, found)
+	}
+	if gotLen := m.Len(); gotLen != 4 {
+		t.Errorf("Len: got %d, want 4", gotLen)
+	}
+	if got, want := slices.Collect(m.Keys()), []string{"b", "a", "c", "d"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Keys: got %v, want %v", got, want)
+	}
+	if got, want := slices.Collect(m.Values()), []int{10, 20, 3, 4}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values: got %v, want %v", got, want)
+	}
+	if got, found := m.Delete("a"); !found || got != 20 {
+		t.Errorf("Delete(a): got (%v, %v), want (20, true)", got, found)
+	}
+	if _, found := m.Delete("a"); found {
+		t.Error("Delete(a) reports found unexpectedly")
+	}
+	m.Put("a", 5) // the key is appended again after deletion
+	var gotKeys []string
+	for k := range m.All() {
+		gotKeys = append(gotKeys, k)
+	}
+	if want := []string{"b", "c", "d", "a"}; !reflect.DeepEqual(want, gotKeys) {
+		t.Errorf("All: got %v, want %v", gotKeys, want)
+	}
+}
+
+func TestShardedOrderedMap_insertionOrderAcrossShards(t *testing.T) {
+	m := coll.NewShardedOrderedMap[int, int](8)
+	want := make([]int, 0, 1000)
+	for i := range 1000 {
+		key := (i * 7919) % 1000
+		m.Put(key, i)
+		want = append(want, key)
+	}
+	if got := slices.Collect(m.Keys()); !reflect.DeepEqual(want, got) {
+		t.Errorf("Keys are not in insertion order: %v", got[:10])
+	}
+	var visited []int
+	m.DeleteFunc(func(key, _ int) bool {
+		visited = append(visited, key)
+		return key%2 == 0
+	})
+	if !reflect.DeepEqual(want, visited) {
+		t.Errorf("DeleteFunc visits the entries out of insertion order: %v", visited[:10])
+	}
+	want = slices.DeleteFunc(want, func(key int) bool { return key%2 == 0 })
+	if got := slices.Collect(m.Keys()); !reflect.DeepEqual(want, got) {
+		t.Errorf("Keys after DeleteFunc: %v", got[:10])
+	}
+	m.Clear()
+	if gotLen := m.Len(); gotLen != 0 {
+		t.Errorf("Len: got %d, want 0", gotLen)
+	}
+}
+
+func TestShardedOrderedMap_empty(t *testing.T) {
+	m := new(coll.ShardedOrderedMap[string, int])
+	if _, found := m.Get("a"); found {
+		t.Error("Get(a) reports found unexpectedly")
+	}
+	m.Put("a", 1)
+	if got, _ := m.Get("a"); got != 1 {
+		t.Errorf("Get(a): got %v, want 1", got)
+	}
+}
+
+func TestShardedOrderedMap_concurrentAccess(t *testing.T) {
+	m := coll.NewShardedOrderedMap[int, int](0)
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range 100 {
+				m.Set(i*100+n, n)
+				if n%3 == 0 {
+					m.Delete(i*100 + n)
+				}
+			}
+		}()
+	}
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_ = m.Len()
+				_, _ = m.Get(42)
+				for k, v := range m.All() {
+					_, _ = k, v
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if got := m.Len(); got != 4*66 {
+		t.Errorf("Len() returns unexpected value: %d", got)
+	}
+}
+
+func BenchmarkShardedOrderedMap_parallel(b *testing.B) {
+	const size = 1 << 16
+	b.Run("OrderedMap", func(b *testing.B) {
+		m := coll.NewOrderedMap[int, int]()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				id := rand.IntN(size)
+				if _, found := m.Get(id); !found {
+					m.Put(id, id)
+				}
+			}
+		})
+	})
+	b.Run("ShardedOrderedMap", func(b *testing.B) {
+		m := coll.NewShardedOrderedMap[int, int](0)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				id := rand.IntN(size)
+				if _, found := m.Get(id); !found {
+					m.Put(id, id)
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,152 @@
+package coll
+
+import (
+	"hash/maphash"
+	"iter"
+	"sync"
+)
+
+// NewShardedSet returns a new [ShardedSet] with the given number of shards.
+// The number is rounded up to a power of two, and a number of zero or less selects a default that scales with GOMAXPROCS.
+func NewShardedSet[E comparable](shards int) *ShardedSet[E] {
+	s := &ShardedSet[E]{
+		shards: nil,
+		seed:   maphash.Seed{},
+		once:   sync.Once{},
+	}
+	s.once.Do(func() { s.init(shards) })
+	return s
+}
+
+// ShardedSet represents a set of comparable elements that is split into independently locked shards.
+// It is safe for concurrent use, and unlike [Set], goroutines that touch elements in different shards do not contend for the same lock.
+// The zero value is an empty set with the default number of shards.
+//
+// Elements are assigned to shards by [maphash.Comparable]. Operations on a single element lock only its shard,
+// whereas Len and the iterators read-lock every shard, so they observe a consistent state of the whole set.
+// Iterators hold the read locks for the duration of the iteration, so the set must not be modified from within the loop body.
+type ShardedSet[E comparable] struct {
+	shards []setShard[E]
+	seed   maphash.Seed
+	once   sync.Once
+}
+
+type setShard[E comparable] struct {
+	set UnsyncSet[E]
+	mux sync.RWMutex
+	_   cacheLinePad
+}
+
+func (s *ShardedSet[E]) init(shards int) {
+	s.shards = make([]setShard[E], shardCount(shards))
+	s.seed = maphash.MakeSeed()
+}
+
+func (s *ShardedSet[E]) shardFor(el E) *setShard[E] {
+	s.once.Do(func() { s.init(0) })
+	return &s.shards[shardIndex(s.seed, el, len(s.shards))]
+}
+
+// rlockAll read-locks every shard in index order and returns the function that releases them.
+func (s *ShardedSet[E]) rlockAll() func() {
+	s.once.Do(func() { s.init(0) })
+	for i := range s.shards {
+		s.shards[i].mux.RLock()
+	}
+	return func() {
+		for i := range s.shards {
+			s.shards[i].mux.RUnlock()
+		}
+	}
+}
+
+// Len returns the number of elements in the set.
+// It is safe for concurrent use.
+func (s *ShardedSet[E]) Len() int {
+	defer s.rlockAll()()
+	n := 0
+	for i := range s.shards {
+		n += s.shards[i].set.Len()
+	}
+	return n
+}
+
+// Contains reports whether the element is present in the set.
+// It is safe for concurrent use.
+func (s *ShardedSet[E]) Contains(el E) bool {
+	shard := s.shardFor(el)
+	shard.mux.RLock()
+	defer shard.mux.RUnlock()
+	return shard.set.Contains(el)
+}
+
+// Append adds the element to the set if it does not already exist.
+// It is safe for concurrent use.
+func (s *ShardedSet[E]) Append(el E) {
+	shard := s.shardFor(el)
+	shard.mux.Lock()
+	defer shard.mux.Unlock()
+	shard.set.Append(el)
+}
+
+// Values returns an iterator over the elements of the set.
+// It is safe for concurrent use.
+func (s *ShardedSet[E]) Values() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		defer s.rlockAll()()
+		for i := range s.shards {
+			for el := range s.shards[i].set.Values() {
+				if !yield(el) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Remove removes the element from the set if it exists.
+// It is safe for concurrent use.
+func (s *ShardedSet[E]) Remove(removedEl E) {
+	shard := s.shardFor(removedEl)
+	shard.mux.Lock()
+	defer shard.mux.Unlock()
+	shard.set.Remove(removedEl)
+}
+
+// Clear removes all elements from the set.
+// It is safe for concurrent use.
+func (s *ShardedSet[E]) Clear() {
+	s.once.Do(func() { s.init(0) })
+	for i := range s.shards {
+		s.shards[i].mux.Lock()
+	}
+	for i := range s.shards {
+		s.shards[i].set.Clear()
+		s.shards[i].mux.Unlock()
+	}
+}
+
+// IsSubset reports whether every element of s is also in other.
+func (s *ShardedSet[E]) IsSubset(other SetLike[E]) bool {
+	return IsSubset[E](s, other)
+}
+
+// IsProperSubset reports whether s is a subset of other and other has at least one element that s does not have.
+func (s *ShardedSet[E]) IsProperSubset(other SetLike[E]) bool {
+	return IsProperSubset[E](s, other)
+}
+
+// IsSuperset reports whether every element of other is also in s.
+func (s *ShardedSet[E]) IsSuperset(other SetLike[E]) bool {
+	return IsSuperset[E](s, other)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+func (s *ShardedSet[E]) IsDisjoint(other SetLike[E]) bool {
+	return IsDisjoint[E](s, other)
+}
+
+// Equal reports whether s and other contain the same elements, regardless of their order.
+func (s *ShardedSet[E]) Equal(other SetLike[E]) bool {
+	return Equal[E](s, other)
+}
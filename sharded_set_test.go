@@ -0,0 +1,141 @@
+package coll_test
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func TestShardedSet(t *testing.T) {
+	for _, shards := range []int{0, 1, 3, 16} {
+		t.Run(strconv.Itoa(shards), func(t *testing.T) {
+			strSet := coll.NewShardedSet[string](shards)
+			for _, el := range []string{"c", "a", "b", "a"} {
+				strSet.Append(el)
+			}
+			if gotLen := strSet.Len(); gotLen != 3 {
+				t.Errorf("Len() returns unexpected value: %d", gotLen)
+			}
+			if got, want := slices.Sorted(strSet.Values()), []string{"a", "b", "c"}; !reflect.DeepEqual(want, got) {
+				t.Errorf("Values() returns the unexpected value:\n\twant: %#v\n\t got: %#v", want, got)
+			}
+			if !strSet.Contains("a") {
+				t.Error("the set says it DOES NOT contain 'a'")
+			}
+			strSet.Remove("a")
+			if strSet.Contains("a") {
+				t.Error("the set says it DOES contain 'a'")
+			}
+			strSet.Clear()
+			if gotLen := strSet.Len(); gotLen != 0 {
+				t.Errorf("Len() returns unexpected value: %d", gotLen)
+			}
+		})
+	}
+}
+
+func TestShardedSet_empty(t *testing.T) {
+	nums := new(coll.ShardedSet[int])
+	if got := nums.Contains(42); got {
+		t.Errorf("Contains(42) reports true unexpectedly")
+	}
+	nums.Append(42)
+	if got, want := slices.Collect(nums.Values()), []int{42}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values() returns the unexpected value:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+func TestShardedSet_predicates(t *testing.T) {
+	xs := coll.NewShardedSet[string](4)
+	xs.Append("a")
+	xs.Append("b")
+	if !xs.IsSubset(coll.NewOrderedSet("b", "a", "c")) {
+		t.Error("IsSubset() reports false unexpectedly")
+	}
+	if !xs.IsProperSubset(coll.NewSet("a", "b", "c")) {
+		t.Error("IsProperSubset() reports false unexpectedly")
+	}
+	if !xs.IsSuperset(coll.NewSet("a")) {
+		t.Error("IsSuperset() reports false unexpectedly")
+	}
+	if !xs.IsDisjoint(coll.NewSet("c")) {
+		t.Error("IsDisjoint() reports false unexpectedly")
+	}
+	if !xs.Equal(coll.NewOrderedSet("b", "a")) {
+		t.Error("Equal() reports false unexpectedly")
+	}
+	if got := coll.Union[string](xs, coll.NewSet("c")); got.Len() != 3 {
+		t.Errorf("Union() returns unexpected number of elements: %d", got.Len())
+	}
+}
+
+func TestShardedSet_concurrentAccess(t *testing.T) {
+	nums := coll.NewShardedSet[int](8)
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range 100 {
+				nums.Append(i*100 + n)
+				if n%3 == 0 {
+					nums.Remove(i*100 + n)
+				}
+			}
+		}()
+	}
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				// Len and Values must agree since both see every shard at once
+				n := 0
+				for range nums.Values() {
+					n++
+				}
+				_ = nums.Contains(42)
+				_ = n
+				_ = nums.Len()
+			}
+		}()
+	}
+	wg.Wait()
+	if got := nums.Len(); got != 4*66 {
+		t.Errorf("Len() returns unexpected value: %d", got)
+	}
+	if got := len(slices.Collect(nums.Values())); got != 4*66 {
+		t.Errorf("Values() yields unexpected number of elements: %d", got)
+	}
+}
+
+func BenchmarkShardedSet_parallel(b *testing.B) {
+	const size = 1 << 16
+	b.Run("Set", func(b *testing.B) {
+		s := coll.NewSet[int]()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				id := rand.IntN(size)
+				if !s.Contains(id) {
+					s.Append(id)
+				}
+			}
+		})
+	})
+	b.Run("ShardedSet", func(b *testing.B) {
+		s := coll.NewShardedSet[int](0)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				id := rand.IntN(size)
+				if !s.Contains(id) {
+					s.Append(id)
+				}
+			}
+		})
+	})
+}
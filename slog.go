@@ -0,0 +1,219 @@
+package coll
+
+import (
+	"container/heap"
+	"fmt"
+	"iter"
+	"log/slog"
+	"slices"
+)
+
+// DefaultLogValueLimit is the maximum number of elements or entries that the LogValue methods include.
+// Use [WithLogValueLimit] to log a collection with another limit.
+const DefaultLogValueLimit = 100
+
+// LimitedLogValuer is implemented by the collections that truncate their elements or entries in the log.
+type LimitedLogValuer interface {
+	slog.LogValuer
+	// LimitedLogValue returns the value that LogValue returns, including at most limit elements or entries instead of [DefaultLogValueLimit].
+	// A limit of zero or less disables truncation.
+	LimitedLogValue(limit int) slog.Value
+}
+
+var (
+	_ LimitedLogValuer = (*Set[int])(nil)
+	_ LimitedLogValuer = (*OrderedSet[int])(nil)
+	_ LimitedLogValuer = (*OrderedMap[int, int])(nil)
+	_ LimitedLogValuer = (*UnsyncSet[int])(nil)
+	_ LimitedLogValuer = (*UnsyncOrderedSet[int])(nil)
+	_ LimitedLogValuer = (*UnsyncOrderedMap[int, int])(nil)
+)
+
+// WithLogValueLimit returns a [slog.LogValuer] that logs v with the given limit, such as
+// slog.Any("tags", coll.WithLogValueLimit(tags, 10)).
+// A limit of zero or less disables truncation.
+func WithLogValueLimit(v LimitedLogValuer, limit int) slog.LogValuer {
+	return limitedLogValue{v: v, limit: limit}
+}
+
+type limitedLogValue struct {
+	v     LimitedLogValuer
+	limit int
+}
+
+func (l limitedLogValue) LogValue() slog.Value {
+	return l.v.LimitedLogValue(l.limit)
+}
+
+// LogValue implements [slog.LogValuer].
+// The set is logged as a group of "len", the number of elements, and "values", the list of at most [DefaultLogValueLimit] elements.
+//
+// If the elements are of an integer, float or string type, the smallest ones are logged in ascending order.
+// They are selected while iterating, so that logging a large set takes no more memory than the limit.
+// Otherwise the elements are logged in the iteration order of the set, so which ones are logged is unspecified.
+func (s *Set[E]) LogValue() slog.Value {
+	return s.LimitedLogValue(DefaultLogValueLimit)
+}
+
+// LimitedLogValue implements [LimitedLogValuer].
+func (s *Set[E]) LimitedLogValue(limit int) slog.Value {
+	return unorderedSetLogValue[E](s, limit)
+}
+
+// LogValue implements [slog.LogValuer].
+// The set is logged as a group of "len", the number of elements, and "values", the list of at most [DefaultLogValueLimit] elements
+// in insertion order.
+func (s *OrderedSet[E]) LogValue() slog.Value {
+	return s.LimitedLogValue(DefaultLogValueLimit)
+}
+
+// LimitedLogValue implements [LimitedLogValuer].
+func (s *OrderedSet[E]) LimitedLogValue(limit int) slog.Value {
+	return orderedSetLogValue[E](s, limit)
+}
+
+// LogValue implements [slog.LogValuer].
+// The map is logged as a group of "len", the number of entries, and "entries", the group of at most [DefaultLogValueLimit] entries
+// in insertion order. Keys are converted to attribute keys in the same way as [OrderedMap.MarshalJSON], falling back to [fmt.Sprint].
+func (m *OrderedMap[K, V]) LogValue() slog.Value {
+	return m.LimitedLogValue(DefaultLogValueLimit)
+}
+
+// LimitedLogValue implements [LimitedLogValuer].
+func (m *OrderedMap[K, V]) LimitedLogValue(limit int) slog.Value {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return m.unsync.LimitedLogValue(limit)
+}
+
+// LogValue implements [slog.LogValuer] in the same way as [Set.LogValue].
+func (s *UnsyncSet[E]) LogValue() slog.Value {
+	return s.LimitedLogValue(DefaultLogValueLimit)
+}
+
+// LimitedLogValue implements [LimitedLogValuer].
+func (s *UnsyncSet[E]) LimitedLogValue(limit int) slog.Value {
+	return unorderedSetLogValue[E](s, limit)
+}
+
+// LogValue implements [slog.LogValuer] in the same way as [OrderedSet.LogValue].
+func (s *UnsyncOrderedSet[E]) LogValue() slog.Value {
+	return s.LimitedLogValue(DefaultLogValueLimit)
+}
+
+// LimitedLogValue implements [LimitedLogValuer].
+func (s *UnsyncOrderedSet[E]) LimitedLogValue(limit int) slog.Value {
+	return orderedSetLogValue[E](s, limit)
+}
+
+// LogValue implements [slog.LogValuer] in the same way as [OrderedMap.LogValue].
+func (m *UnsyncOrderedMap[K, V]) LogValue() slog.Value {
+	return m.LimitedLogValue(DefaultLogValueLimit)
+}
+
+// LimitedLogValue implements [LimitedLogValuer].
+func (m *UnsyncOrderedMap[K, V]) LimitedLogValue(limit int) slog.Value {
+	n := m.Len()
+	attrs := make([]slog.Attr, 0, logValueCap(n, limit))
+	for key, value := range m.All() {
+		if limit > 0 && len(attrs) >= limit {
+			break
+		}
+		attrs = append(attrs, slog.Any(logKey(key), value))
+	}
+	return slog.GroupValue(slog.Int("len", n), slog.Attr{Key: "entries", Value: slog.GroupValue(attrs...)})
+}
+
+// orderedSetLogValue logs the first limit elements of s in its iteration order.
+func orderedSetLogValue[E comparable](s SetLike[E], limit int) slog.Value {
+	n := s.Len()
+	els := make([]E, 0, logValueCap(n, limit))
+	for el := range s.Values() {
+		if limit > 0 && len(els) >= limit {
+			break
+		}
+		els = append(els, el)
+	}
+	return setLogValue(n, els)
+}
+
+// unorderedSetLogValue logs the smallest limit elements of s if they are of an ordered type,
+// so that the logged subset does not depend on the iteration order, and the first limit elements otherwise.
+func unorderedSetLogValue[E comparable](s SetLike[E], limit int) slog.Value {
+	compare := orderedCompare[E]()
+	if compare == nil {
+		return orderedSetLogValue(s, limit)
+	}
+	n := s.Len()
+	if limit <= 0 || n <= limit {
+		els := make([]E, 0, n)
+		els = slices.AppendSeq(els, s.Values())
+		slices.SortFunc(els, compare)
+		return setLogValue(len(els), els)
+	}
+	return setLogValue(n, smallest(s.Values(), limit, compare))
+}
+
+func setLogValue[E comparable](n int, els []E) slog.Value {
+	return slog.GroupValue(slog.Int("len", n), slog.Any("values", els))
+}
+
+// smallest returns the k smallest values of seq in ascending order.
+// It keeps at most k values at a time in a max-heap, so it runs in O(n log k) time and O(k) space.
+func smallest[E any](seq iter.Seq[E], k int, compare func(a, b E) int) []E {
+	h := &maxHeap[E]{values: make([]E, 0, k), compare: compare}
+	for v := range seq {
+		switch {
+		case len(h.values) < k:
+			h.values = append(h.values, v)
+			if len(h.values) == k {
+				heap.Init(h)
+			}
+		case compare(v, h.values[0]) < 0:
+			h.values[0] = v
+			heap.Fix(h, 0)
+		}
+	}
+	slices.SortFunc(h.values, compare)
+	return h.values
+}
+
+// maxHeap is a heap whose root is the largest value.
+type maxHeap[E any] struct {
+	compare func(a, b E) int
+	values  []E
+}
+
+var _ heap.Interface = (*maxHeap[int])(nil)
+
+func (h *maxHeap[E]) Len() int { return len(h.values) }
+
+func (h *maxHeap[E]) Less(i, j int) bool { return h.compare(h.values[i], h.values[j]) > 0 }
+
+func (h *maxHeap[E]) Swap(i, j int) { h.values[i], h.values[j] = h.values[j], h.values[i] }
+
+func (h *maxHeap[E]) Push(x any) {
+	if v, ok := x.(E); ok {
+		h.values = append(h.values, v)
+	}
+}
+
+func (h *maxHeap[E]) Pop() any {
+	last := h.values[len(h.values)-1]
+	h.values = h.values[:len(h.values)-1]
+	return last
+}
+
+func logValueCap(n, limit int) int {
+	if limit > 0 && n > limit {
+		return limit
+	}
+	return n
+}
+
+func logKey[K comparable](key K) string {
+	if name, err := marshalMapKey(key); err == nil {
+		return name
+	}
+	return fmt.Sprint(key)
+}
@@ -0,0 +1,131 @@
+package coll_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func logJSON(t *testing.T, value any) string {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("", slog.Any("v", value))
+	return buf.String()
+}
+
+func TestSet_LogValue(t *testing.T) {
+	testCases := []struct {
+		value any
+		name  string
+		want  string
+	}{
+		{name: "Set", value: coll.NewSet("c", "a", "b"), want: `{"v":{"len":3,"values":["a","b","c"]}}` + "\n"},
+		{name: "OrderedSet", value: coll.NewOrderedSet("c", "a", "b"), want: `{"v":{"len":3,"values":["c","a","b"]}}` + "\n"},
+		{name: "empty", value: coll.NewOrderedSet[string](), want: `{"v":{"len":0,"values":[]}}` + "\n"},
+		{name: "truncated", value: coll.WithLogValueLimit(coll.NewOrderedSet(5, 4, 3, 2, 1), 3), want: `{"v":{"len":5,"values":[5,4,3]}}` + "\n"},
+		{name: "truncated Set", value: coll.WithLogValueLimit(coll.NewSet(50, 40, 30, 20, 10, 60, 70, 80), 3), want: `{"v":{"len":8,"values":[10,20,30]}}` + "\n"},
+		{name: "truncated UnsyncSet", value: coll.WithLogValueLimit(coll.NewUnsyncSet(50, 40, 30, 20, 10, 60, 70, 80), 3), want: `{"v":{"len":8,"values":[10,20,30]}}` + "\n"},
+		{name: "truncation disabled", value: coll.WithLogValueLimit(coll.NewSet(3, 1, 2), 0), want: `{"v":{"len":3,"values":[1,2,3]}}` + "\n"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := logJSON(t, tc.value); got != tc.want {
+				t.Errorf("mismatch:\n\twant: %s\n\t got: %s", tc.want, got)
+			}
+		})
+	}
+	t.Run("default limit", func(t *testing.T) {
+		s := coll.NewSet[int]()
+		for i := range coll.DefaultLogValueLimit * 2 {
+			s.Append(coll.DefaultLogValueLimit*2 - i)
+		}
+		var got struct {
+			V struct {
+				Values []int `json:"values"`
+				Len    int   `json:"len"`
+			} `json:"v"`
+		}
+		if err := json.Unmarshal([]byte(logJSON(t, s)), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.V.Len != coll.DefaultLogValueLimit*2 {
+			t.Errorf("len = %d, want %d", got.V.Len, coll.DefaultLogValueLimit*2)
+		}
+		want := make([]int, 0, coll.DefaultLogValueLimit)
+		for i := range coll.DefaultLogValueLimit {
+			want = append(want, i+1)
+		}
+		if !slices.Equal(want, got.V.Values) {
+			t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got.V.Values)
+		}
+	})
+	t.Run("truncated Set of unordered type", func(t *testing.T) {
+		type point struct{ X, Y int }
+		got := logJSON(t, coll.WithLogValueLimit(coll.NewSet(point{1, 2}, point{3, 4}), 1))
+		if !strings.HasPrefix(got, `{"v":{"len":2,"values":[{"X":`) || strings.Count(got, `"X"`) != 1 {
+			t.Errorf("unexpected log: %s", got)
+		}
+	})
+}
+
+func TestOrderedMap_LogValue(t *testing.T) {
+	t.Run("entries", func(t *testing.T) {
+		m := coll.NewOrderedMap[string, any]()
+		m.Put("z", 1)
+		m.Put("a", coll.NewOrderedSet("y", "x"))
+		want := `{"v":{"len":2,"entries":{"z":1,"a":{"len":2,"values":["y","x"]}}}}` + "\n"
+		if got := logJSON(t, m); got != want {
+			t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+		}
+	})
+	t.Run("integer keys", func(t *testing.T) {
+		m := coll.NewOrderedMap[int, string]()
+		m.Put(2, "b")
+		want := `{"v":{"len":1,"entries":{"2":"b"}}}` + "\n"
+		if got := logJSON(t, m); got != want {
+			t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+		}
+	})
+	t.Run("truncated", func(t *testing.T) {
+		m := coll.NewOrderedMap[string, int]()
+		m.Put("c", 3)
+		m.Put("b", 2)
+		m.Put("a", 1)
+		want := `{"v":{"len":3,"entries":{"c":3,"b":2}}}` + "\n"
+		if got := logJSON(t, coll.WithLogValueLimit(m, 2)); got != want {
+			t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+		}
+	})
+	t.Run("truncation disabled", func(t *testing.T) {
+		m := coll.NewOrderedMap[string, int]()
+		m.Put("c", 3)
+		m.Put("b", 2)
+		m.Put("a", 1)
+		want := `{"v":{"len":3,"entries":{"c":3,"b":2,"a":1}}}` + "\n"
+		if got := logJSON(t, coll.WithLogValueLimit(m, 0)); got != want {
+			t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+		}
+	})
+	t.Run("keys named like the metadata", func(t *testing.T) {
+		m := coll.NewOrderedMap[string, int]()
+		m.Put("len", 1)
+		m.Put("entries", 2)
+		want := `{"v":{"len":2,"entries":{"len":1,"entries":2}}}` + "\n"
+		if got := logJSON(t, m); got != want {
+			t.Errorf("mismatch:\n\twant: %s\n\t got: %s", want, got)
+		}
+	})
+}
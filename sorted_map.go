@@ -0,0 +1,255 @@
+package coll
+
+import (
+	"cmp"
+	"iter"
+	"sync"
+)
+
+// NewSortedMap returns a new empty [SortedMap] whose keys are ordered by [cmp.Compare].
+func NewSortedMap[K cmp.Ordered, V any]() *SortedMap[K, V] {
+	return &SortedMap[K, V]{sortedMap: newSortedMap[K, V, naturalOrder[K]](cmp.Compare[K])}
+}
+
+// NewSortedMapFunc returns a new empty [SortedMapFunc] whose keys are ordered by compare.
+// compare must define a strict weak ordering, and keys that compare as equal are regarded as the same key.
+func NewSortedMapFunc[K comparable, V any](compare func(a, b K) int) *SortedMapFunc[K, V] {
+	return &SortedMapFunc[K, V]{sortedMap: newSortedMap[K, V, unsetOrder[K]](compare)}
+}
+
+// SortedMap represents a map whose naturally ordered keys are kept sorted by [cmp.Compare].
+// It is safe for concurrent use, and the zero value is an empty map.
+//
+// It is backed by a B-tree, so that lookups and updates run in O(log n).
+//
+// Iterators returned by the map hold the read lock for the duration of the iteration,
+// so the map must not be modified from within the loop body.
+type SortedMap[K cmp.Ordered, V any] struct {
+	sortedMap[K, V, naturalOrder[K]]
+}
+
+// SortedMapFunc represents a map whose keys are kept sorted by a comparison function.
+// It offers the same API as [SortedMap] and is safe for concurrent use.
+// It must be created by [NewSortedMapFunc], since the zero value has no comparison function;
+// adding an entry to the zero value panics.
+type SortedMapFunc[K comparable, V any] struct {
+	sortedMap[K, V, unsetOrder[K]]
+}
+
+// sortedMap implements [SortedMap] and [SortedMapFunc]; O provides the comparison function of the zero value.
+type sortedMap[K comparable, V any, O orderOf[K]] struct {
+	tree btree[K, V]
+	mux  sync.RWMutex
+}
+
+func newSortedMap[K comparable, V any, O orderOf[K]](compare func(a, b K) int) sortedMap[K, V, O] {
+	return sortedMap[K, V, O]{
+		tree: btree[K, V]{root: nil, compare: compare},
+		mux:  sync.RWMutex{},
+	}
+}
+
+// ensureOrder sets the comparison function of the zero value. The write lock must be held.
+func (m *sortedMap[K, V, O]) ensureOrder() {
+	if m.tree.compare == nil {
+		var order O
+		m.tree.compare = order.comparator()
+	}
+}
+
+// Len returns the number of entries in the map.
+// It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) Len() int {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return m.tree.len()
+}
+
+// Get retrieves the value associated with the given key.
+// The second return value indicates whether the key was found.
+// It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) Get(key K) (V, bool) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return valueOf(m.tree.get(key))
+}
+
+// Put inserts the key-value pair into the map if the key does not already exist.
+// It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) Put(key K, value V) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if _, found := m.tree.get(key); !found {
+		m.ensureOrder()
+		m.tree.set(key, value)
+	}
+}
+
+// Set associates the value with the key, overwriting the existing value if any.
+// It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) Set(key K, value V) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.ensureOrder()
+	m.tree.set(key, value)
+}
+
+// Update updates the value associated with the key using the provided function.
+// The updater function receives the current value (or zero value if not found) and a boolean indicating existence.
+// It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) Update(key K, update func(prev V, alreadyExist bool) V) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if item, found := m.tree.get(key); found {
+		item.value = update(item.value, true)
+		return
+	}
+	var zero V
+	m.ensureOrder()
+	m.tree.set(key, update(zero, false))
+}
+
+// Delete removes the key from the map and returns the value that was associated with it.
+// The second return value indicates whether the key was found. It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) Delete(key K) (V, bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	removed, found := m.tree.delete(key)
+	return removed.value, found
+}
+
+// DeleteFunc removes every entry for which del returns true.
+// It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) DeleteFunc(del func(key K, value V) bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	var keys []K
+	m.tree.ascend(nil, func(item *btreeItem[K, V]) bool {
+		if del(item.key, item.value) {
+			keys = append(keys, item.key)
+		}
+		return true
+	})
+	for _, key := range keys {
+		m.tree.delete(key)
+	}
+}
+
+// Clear removes all entries from the map.
+// It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) Clear() {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.tree.root = nil
+}
+
+// PopMin removes the entry with the smallest key from the map and returns it.
+// The third return value is false if the map is empty. It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) PopMin() (K, V, bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return m.pop(m.tree.min())
+}
+
+// PopMax removes the entry with the largest key from the map and returns it.
+// The third return value is false if the map is empty. It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) PopMax() (K, V, bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return m.pop(m.tree.max())
+}
+
+func (m *sortedMap[K, V, O]) pop(item *btreeItem[K, V], found bool) (K, V, bool) {
+	if found {
+		removed, _ := m.tree.delete(item.key)
+		item = &removed
+	}
+	return entryOf(item, found)
+}
+
+// FloorEntry returns the entry with the largest key less than or equal to key.
+// The third return value is false if there is no such entry. It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) FloorEntry(key K) (K, V, bool) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return entryOf(m.tree.floor(key))
+}
+
+// CeilingEntry returns the entry with the smallest key greater than or equal to key.
+// The third return value is false if there is no such entry. It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) CeilingEntry(key K) (K, V, bool) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return entryOf(m.tree.ceiling(key))
+}
+
+// Keys returns an iterator over the keys in ascending order.
+// It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.mux.RLock()
+		defer m.mux.RUnlock()
+		m.tree.ascend(nil, func(item *btreeItem[K, V]) bool { return yield(item.key) })
+	}
+}
+
+// Values returns an iterator over the values in ascending order of their corresponding keys.
+// It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.mux.RLock()
+		defer m.mux.RUnlock()
+		m.tree.ascend(nil, func(item *btreeItem[K, V]) bool { return yield(item.value) })
+	}
+}
+
+// All returns an iterator over key-value pairs in ascending order of the keys.
+// It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.mux.RLock()
+		defer m.mux.RUnlock()
+		m.tree.ascend(nil, func(item *btreeItem[K, V]) bool { return yield(item.key, item.value) })
+	}
+}
+
+// Backward returns an iterator over key-value pairs in descending order of the keys.
+// It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.mux.RLock()
+		defer m.mux.RUnlock()
+		m.tree.descend(func(item *btreeItem[K, V]) bool { return yield(item.key, item.value) })
+	}
+}
+
+// Range returns an iterator over the key-value pairs whose keys are greater than or equal to lo and less than hi,
+// in ascending order of the keys. It is safe for concurrent use.
+func (m *sortedMap[K, V, O]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.mux.RLock()
+		defer m.mux.RUnlock()
+		m.tree.ascend(&lo, func(item *btreeItem[K, V]) bool {
+			return m.tree.compare(item.key, hi) < 0 && yield(item.key, item.value)
+		})
+	}
+}
+
+func valueOf[K, V any](item *btreeItem[K, V], found bool) (V, bool) {
+	if !found {
+		var zero V
+		return zero, false
+	}
+	return item.value, true
+}
+
+func entryOf[K, V any](item *btreeItem[K, V], found bool) (K, V, bool) {
+	if !found {
+		var (
+			zeroKey   K
+			zeroValue V
+		)
+		return zeroKey, zeroValue, false
+	}
+	return item.key, item.value, true
+}
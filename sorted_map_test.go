@@ -0,0 +1,236 @@
+package coll_test
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func TestSortedMap(t *testing.T) {
+	m := coll.NewSortedMap[int, string]()
+	m.Put(30, "c")
+	m.Put(10, "a")
+	m.Put(20, "b")
+	m.Put(10, "ignored")
+	m.Set(40, "d")
+	m.Set(20, "B")
+	m.Update(30, func(prev string, alreadyExist bool) string {
+		if !alreadyExist {
+			t.Error("Update() reports that an existing key does not exist")
+		}
+		return prev + "!"
+	})
+	m.Update(50, func(prev string, alreadyExist bool) string {
+		if alreadyExist || prev != "" {
+			t.Errorf("Update() passes (%q, %v) for a new key", prev, alreadyExist)
+		}
+		return "e"
+	})
+	if got, want := slices.Collect(m.Keys()), []int{10, 20, 30, 40, 50}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Keys():\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if got, want := slices.Collect(m.Values()), []string{"a", "B", "c!", "d", "e"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values():\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if got, want := maps.Collect(m.All()), map[int]string{10: "a", 20: "B", 30: "c!", 40: "d", 50: "e"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("All():\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if got, want := collectKeys(m.Backward()), []int{50, 40, 30, 20, 10}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Backward():\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if got, want := collectKeys(m.Range(15, 40)), []int{20, 30}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Range(15, 40):\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if value, ok := m.Get(20); value != "B" || !ok {
+		t.Errorf("Get(20) = (%q, %v)", value, ok)
+	}
+	if value, ok := m.Delete(20); value != "B" || !ok {
+		t.Errorf("Delete(20) = (%q, %v)", value, ok)
+	}
+	if _, ok := m.Delete(20); ok {
+		t.Error("Delete() of a missing key reports that it was found")
+	}
+	m.DeleteFunc(func(key int, _ string) bool { return key == 40 })
+	if got, want := slices.Collect(m.Keys()), []int{10, 30, 50}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Keys() after deletion:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+func TestSortedMap_navigation(t *testing.T) {
+	m := coll.NewSortedMap[int, string]()
+	for _, key := range []int{10, 20, 30} {
+		m.Set(key, string(rune('a'+key/10-1)))
+	}
+	testCases := []struct {
+		name      string
+		method    func(int) (int, string, bool)
+		wantValue string
+		arg       int
+		wantKey   int
+		wantOK    bool
+	}{
+		{name: "FloorEntry/exact", method: m.FloorEntry, arg: 20, wantKey: 20, wantValue: "b", wantOK: true},
+		{name: "FloorEntry/between", method: m.FloorEntry, arg: 25, wantKey: 20, wantValue: "b", wantOK: true},
+		{name: "FloorEntry/below min", method: m.FloorEntry, arg: 5, wantKey: 0, wantValue: "", wantOK: false},
+		{name: "CeilingEntry/exact", method: m.CeilingEntry, arg: 20, wantKey: 20, wantValue: "b", wantOK: true},
+		{name: "CeilingEntry/between", method: m.CeilingEntry, arg: 25, wantKey: 30, wantValue: "c", wantOK: true},
+		{name: "CeilingEntry/above max", method: m.CeilingEntry, arg: 35, wantKey: 0, wantValue: "", wantOK: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, value, ok := tc.method(tc.arg)
+			if key != tc.wantKey || value != tc.wantValue || ok != tc.wantOK {
+				t.Errorf("(%d, %q, %v) returned, want (%d, %q, %v)", key, value, ok, tc.wantKey, tc.wantValue, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestSortedMap_pop(t *testing.T) {
+	m := coll.NewSortedMap[int, string]()
+	if _, _, ok := m.PopMin(); ok {
+		t.Error("PopMin() of an empty map reports an entry")
+	}
+	if _, _, ok := m.PopMax(); ok {
+		t.Error("PopMax() of an empty map reports an entry")
+	}
+	m.Set(2, "b")
+	m.Set(1, "a")
+	m.Set(3, "c")
+	if key, value, ok := m.PopMin(); key != 1 || value != "a" || !ok {
+		t.Errorf("PopMin() = (%d, %q, %v)", key, value, ok)
+	}
+	if key, value, ok := m.PopMax(); key != 3 || value != "c" || !ok {
+		t.Errorf("PopMax() = (%d, %q, %v)", key, value, ok)
+	}
+	if got, want := slices.Collect(m.Keys()), []int{2}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Keys():\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	m.Clear()
+	if m.Len() != 0 {
+		t.Errorf("Len() = %d after Clear()", m.Len())
+	}
+}
+
+func TestSortedMap_zero(t *testing.T) {
+	testCases := []struct {
+		add  func(m *coll.SortedMap[int, string], key int, value string)
+		name string
+	}{
+		{name: "Put", add: (*coll.SortedMap[int, string]).Put},
+		{name: "Set", add: (*coll.SortedMap[int, string]).Set},
+		{name: "Update", add: func(m *coll.SortedMap[int, string], key int, value string) {
+			m.Update(key, func(string, bool) string { return value })
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var m coll.SortedMap[int, string]
+			if m.Len() != 0 {
+				t.Error("the zero value is not empty")
+			}
+			if _, _, ok := m.PopMin(); ok {
+				t.Error("PopMin() of the zero value reports an entry")
+			}
+			tc.add(&m, 2, "b")
+			tc.add(&m, 1, "a")
+			if got, want := slices.Collect(m.Values()), []string{"a", "b"}; !reflect.DeepEqual(want, got) {
+				t.Errorf("Values():\n\twant: %#v\n\t got: %#v", want, got)
+			}
+		})
+	}
+}
+
+func TestSortedMapFunc_zero(t *testing.T) {
+	var m coll.SortedMapFunc[int, string]
+	if _, ok := m.Get(1); ok || m.Len() != 0 {
+		t.Error("the zero value is not empty")
+	}
+	defer func() {
+		msg, ok := recover().(string)
+		if !ok || !strings.Contains(msg, "comparison function is not set") {
+			t.Errorf("Set() to the zero value panics with %#v", msg)
+		}
+	}()
+	m.Set(1, "a")
+}
+
+func TestSortedMapFunc(t *testing.T) {
+	type version struct{ major, minor int }
+	m := coll.NewSortedMapFunc[version, string](func(a, b version) int {
+		return cmp.Or(cmp.Compare(a.major, b.major), cmp.Compare(a.minor, b.minor))
+	})
+	m.Set(version{1, 10}, "1.10")
+	m.Set(version{1, 2}, "1.2")
+	m.Set(version{2, 0}, "2.0")
+	if got, want := slices.Collect(m.Values()), []string{"1.2", "1.10", "2.0"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values():\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if _, value, _ := m.FloorEntry(version{1, 99}); value != "1.10" {
+		t.Errorf("FloorEntry() = %q, want 1.10", value)
+	}
+}
+
+func TestSortedMap_model(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 8))
+	m := coll.NewSortedMap[int, int]()
+	model := map[int]int{}
+	for i := range 20000 {
+		key := rng.IntN(1000)
+		switch rng.IntN(4) {
+		case 0:
+			_, wantOK := model[key]
+			delete(model, key)
+			if _, ok := m.Delete(key); ok != wantOK {
+				t.Fatalf("Delete(%d) reports %v, want %v", key, ok, wantOK)
+			}
+		case 1:
+			wantKey, wantValue, wantOK := 0, 0, len(model) > 0
+			if wantOK {
+				wantKey = slices.Min(slices.Collect(maps.Keys(model)))
+				wantValue = model[wantKey]
+				delete(model, wantKey)
+			}
+			if key, value, ok := m.PopMin(); key != wantKey || value != wantValue || ok != wantOK {
+				t.Fatalf("PopMin() = (%d, %d, %v), want (%d, %d, %v)", key, value, ok, wantKey, wantValue, wantOK)
+			}
+		default:
+			model[key] = i
+			m.Set(key, i)
+		}
+		if m.Len() != len(model) {
+			t.Fatalf("Len() = %d, want %d", m.Len(), len(model))
+		}
+	}
+	if got := maps.Collect(m.All()); !reflect.DeepEqual(model, got) {
+		t.Errorf("All() mismatch:\n\twant: %#v\n\t got: %#v", model, got)
+	}
+	if keys := slices.Collect(m.Keys()); !slices.IsSorted(keys) {
+		t.Errorf("Keys() are not sorted: %#v", keys)
+	}
+}
+
+func collectKeys[K, V any](seq iter.Seq2[K, V]) []K {
+	var keys []K
+	for key := range seq {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func BenchmarkSortedMap_Set(b *testing.B) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	m := coll.NewSortedMap[int, int]()
+	b.ReportAllocs()
+	for b.Loop() {
+		key := rng.IntN(1 << 20)
+		m.Set(key, key)
+	}
+}
@@ -0,0 +1,276 @@
+package coll
+
+import (
+	"cmp"
+	"iter"
+	"sync"
+)
+
+// orderOf provides the comparison function that a sorted collection falls back to when its zero value is used.
+type orderOf[K any] interface {
+	comparator() func(a, b K) int
+}
+
+// naturalOrder orders keys by [cmp.Compare].
+type naturalOrder[K cmp.Ordered] struct{}
+
+func (naturalOrder[K]) comparator() func(a, b K) int { return cmp.Compare[K] }
+
+// unsetOrder is the order of the collections that take a comparison function, which their zero values lack.
+type unsetOrder[K any] struct{}
+
+func (unsetOrder[K]) comparator() func(a, b K) int {
+	panic("coll: the comparison function is not set; create the collection with its New...Func constructor")
+}
+
+// NewSortedSet returns a new [SortedSet] containing the provided elements, ordered by [cmp.Compare].
+// Duplicates in the input are ignored.
+func NewSortedSet[E cmp.Ordered](els ...E) *SortedSet[E] {
+	s := &SortedSet[E]{sortedSet: newSortedSet[E, naturalOrder[E]](cmp.Compare[E])}
+	s.appendAll(els)
+	return s
+}
+
+// NewSortedSetFunc returns a new [SortedSetFunc] containing the provided elements, ordered by compare.
+// compare must define a strict weak ordering, and elements that compare as equal are regarded as the same element.
+// Duplicates in the input are ignored.
+func NewSortedSetFunc[E comparable](compare func(a, b E) int, els ...E) *SortedSetFunc[E] {
+	s := &SortedSetFunc[E]{sortedSet: newSortedSet[E, unsetOrder[E]](compare)}
+	s.appendAll(els)
+	return s
+}
+
+// SortedSet represents a set of naturally ordered elements that are kept sorted by [cmp.Compare].
+// It is safe for concurrent use, and the zero value is an empty set.
+//
+// It is backed by a B-tree augmented with subtree sizes, so that lookups, updates, and rank and select queries run in O(log n).
+//
+// Iterators returned by the set hold the read lock for the duration of the iteration,
+// so the set must not be modified from within the loop body.
+type SortedSet[E cmp.Ordered] struct {
+	sortedSet[E, naturalOrder[E]]
+}
+
+// SortedSetFunc represents a set of elements that are kept sorted by a comparison function.
+// It offers the same API as [SortedSet] and is safe for concurrent use.
+// It must be created by [NewSortedSetFunc], since the zero value has no comparison function;
+// adding an element to the zero value panics.
+type SortedSetFunc[E comparable] struct {
+	sortedSet[E, unsetOrder[E]]
+}
+
+// IsSubset reports whether every element of s is also in other.
+func (s *SortedSet[E]) IsSubset(other SetLike[E]) bool {
+	return IsSubset[E](s, other)
+}
+
+// IsProperSubset reports whether s is a subset of other and other has at least one element that s does not have.
+func (s *SortedSet[E]) IsProperSubset(other SetLike[E]) bool {
+	return IsProperSubset[E](s, other)
+}
+
+// IsSuperset reports whether every element of other is also in s.
+func (s *SortedSet[E]) IsSuperset(other SetLike[E]) bool {
+	return IsSuperset[E](s, other)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+func (s *SortedSet[E]) IsDisjoint(other SetLike[E]) bool {
+	return IsDisjoint[E](s, other)
+}
+
+// Equal reports whether s and other contain the same elements, regardless of their order.
+func (s *SortedSet[E]) Equal(other SetLike[E]) bool {
+	return Equal[E](s, other)
+}
+
+// IsSubset reports whether every element of s is also in other.
+func (s *SortedSetFunc[E]) IsSubset(other SetLike[E]) bool {
+	return IsSubset[E](s, other)
+}
+
+// IsProperSubset reports whether s is a subset of other and other has at least one element that s does not have.
+func (s *SortedSetFunc[E]) IsProperSubset(other SetLike[E]) bool {
+	return IsProperSubset[E](s, other)
+}
+
+// IsSuperset reports whether every element of other is also in s.
+func (s *SortedSetFunc[E]) IsSuperset(other SetLike[E]) bool {
+	return IsSuperset[E](s, other)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+func (s *SortedSetFunc[E]) IsDisjoint(other SetLike[E]) bool {
+	return IsDisjoint[E](s, other)
+}
+
+// Equal reports whether s and other contain the same elements, regardless of their order.
+func (s *SortedSetFunc[E]) Equal(other SetLike[E]) bool {
+	return Equal[E](s, other)
+}
+
+// sortedSet implements [SortedSet] and [SortedSetFunc]; O provides the comparison function of the zero value.
+// The methods that pass the set to the package-level functions are declared on the exported types,
+// so that the functions see the same pointer as the caller and recognize a comparison of the set with itself.
+type sortedSet[E comparable, O orderOf[E]] struct {
+	tree btree[E, struct{}]
+	mux  sync.RWMutex
+}
+
+func newSortedSet[E comparable, O orderOf[E]](compare func(a, b E) int) sortedSet[E, O] {
+	return sortedSet[E, O]{
+		tree: btree[E, struct{}]{root: nil, compare: compare},
+		mux:  sync.RWMutex{},
+	}
+}
+
+// sorted marks the set as iterating in sorted order for isOrdered.
+func (s *sortedSet[E, O]) sorted() {}
+
+// ensureOrder sets the comparison function of the zero value. The write lock must be held.
+func (s *sortedSet[E, O]) ensureOrder() {
+	if s.tree.compare == nil {
+		var order O
+		s.tree.compare = order.comparator()
+	}
+}
+
+func (s *sortedSet[E, O]) appendAll(els []E) {
+	if len(els) == 0 {
+		return
+	}
+	s.ensureOrder()
+	for _, el := range els {
+		s.tree.set(el, struct{}{})
+	}
+}
+
+// Len returns the number of elements in the set.
+// It is safe for concurrent use.
+func (s *sortedSet[E, O]) Len() int {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return s.tree.len()
+}
+
+// Contains reports whether the element is present in the set.
+// It is safe for concurrent use.
+func (s *sortedSet[E, O]) Contains(el E) bool {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	_, found := s.tree.get(el)
+	return found
+}
+
+// Append adds the element to the set if it does not already exist.
+// It is safe for concurrent use.
+func (s *sortedSet[E, O]) Append(el E) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.ensureOrder()
+	s.tree.set(el, struct{}{})
+}
+
+// Remove removes the element from the set if it exists.
+// It is safe for concurrent use.
+func (s *sortedSet[E, O]) Remove(removedEl E) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.tree.delete(removedEl)
+}
+
+// Clear removes all elements from the set.
+// It is safe for concurrent use.
+func (s *sortedSet[E, O]) Clear() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.tree.root = nil
+}
+
+// Values returns an iterator over the elements of the set in ascending order.
+// It is safe for concurrent use.
+func (s *sortedSet[E, O]) Values() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		s.mux.RLock()
+		defer s.mux.RUnlock()
+		s.tree.ascend(nil, func(item *btreeItem[E, struct{}]) bool { return yield(item.key) })
+	}
+}
+
+// Backward returns an iterator over the elements of the set in descending order.
+// It is safe for concurrent use.
+func (s *sortedSet[E, O]) Backward() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		s.mux.RLock()
+		defer s.mux.RUnlock()
+		s.tree.descend(func(item *btreeItem[E, struct{}]) bool { return yield(item.key) })
+	}
+}
+
+// Range returns an iterator over the elements greater than or equal to lo and less than hi, in ascending order.
+// It is safe for concurrent use.
+func (s *sortedSet[E, O]) Range(lo, hi E) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		s.mux.RLock()
+		defer s.mux.RUnlock()
+		s.tree.ascend(&lo, func(item *btreeItem[E, struct{}]) bool {
+			return s.tree.compare(item.key, hi) < 0 && yield(item.key)
+		})
+	}
+}
+
+// Min returns the smallest element of the set.
+// The second return value is false if the set is empty. It is safe for concurrent use.
+func (s *sortedSet[E, O]) Min() (E, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return keyOf(s.tree.min())
+}
+
+// Max returns the largest element of the set.
+// The second return value is false if the set is empty. It is safe for concurrent use.
+func (s *sortedSet[E, O]) Max() (E, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return keyOf(s.tree.max())
+}
+
+// Floor returns the largest element of the set less than or equal to el.
+// The second return value is false if there is no such element. It is safe for concurrent use.
+func (s *sortedSet[E, O]) Floor(el E) (E, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return keyOf(s.tree.floor(el))
+}
+
+// Ceiling returns the smallest element of the set greater than or equal to el.
+// The second return value is false if there is no such element. It is safe for concurrent use.
+func (s *sortedSet[E, O]) Ceiling(el E) (E, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return keyOf(s.tree.ceiling(el))
+}
+
+// Rank returns the number of elements of the set less than el, which is the index of el in ascending order if el is in the set.
+// It runs in O(log n) and is safe for concurrent use.
+func (s *sortedSet[E, O]) Rank(el E) int {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return s.tree.rank(el)
+}
+
+// Select returns the element at index i in ascending order, where the smallest element is at index 0.
+// The second return value is false if i is out of range. It runs in O(log n) and is safe for concurrent use.
+func (s *sortedSet[E, O]) Select(i int) (E, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return keyOf(s.tree.at(i))
+}
+
+func keyOf[K, V any](item *btreeItem[K, V], found bool) (K, bool) {
+	if !found {
+		var zero K
+		return zero, false
+	}
+	return item.key, true
+}
@@ -0,0 +1,257 @@
+package coll_test
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func TestSortedSet(t *testing.T) {
+	s := coll.NewSortedSet(5, 1, 9, 3, 7, 3)
+	if got, want := slices.Collect(s.Values()), []int{1, 3, 5, 7, 9}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values():\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if got, want := slices.Collect(s.Backward()), []int{9, 7, 5, 3, 1}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Backward():\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	testCases := []struct {
+		name   string
+		method func(int) (int, bool)
+		arg    int
+		want   int
+		wantOK bool
+	}{
+		{name: "Floor/exact", method: s.Floor, arg: 5, want: 5, wantOK: true},
+		{name: "Floor/between", method: s.Floor, arg: 6, want: 5, wantOK: true},
+		{name: "Floor/below min", method: s.Floor, arg: 0, want: 0, wantOK: false},
+		{name: "Ceiling/exact", method: s.Ceiling, arg: 5, want: 5, wantOK: true},
+		{name: "Ceiling/between", method: s.Ceiling, arg: 6, want: 7, wantOK: true},
+		{name: "Ceiling/above max", method: s.Ceiling, arg: 10, want: 0, wantOK: false},
+		{name: "Select/first", method: s.Select, arg: 0, want: 1, wantOK: true},
+		{name: "Select/last", method: s.Select, arg: 4, want: 9, wantOK: true},
+		{name: "Select/out of range", method: s.Select, arg: 5, want: 0, wantOK: false},
+		{name: "Select/negative", method: s.Select, arg: -1, want: 0, wantOK: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.method(tc.arg)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("(%d, %v) returned, want (%d, %v)", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+	if got, want := slices.Collect(s.Range(3, 9)), []int{3, 5, 7}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Range(3, 9):\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if got := s.Rank(7); got != 3 {
+		t.Errorf("Rank(7) = %d, want 3", got)
+	}
+	if got := s.Rank(4); got != 2 {
+		t.Errorf("Rank(4) = %d, want 2", got)
+	}
+}
+
+func TestSortedSet_empty(t *testing.T) {
+	s := coll.NewSortedSet[int]()
+	if _, ok := s.Min(); ok {
+		t.Error("Min() of an empty set reports an element")
+	}
+	if _, ok := s.Max(); ok {
+		t.Error("Max() of an empty set reports an element")
+	}
+	if s.Len() != 0 || s.Contains(0) || s.Rank(0) != 0 {
+		t.Error("the set is not empty")
+	}
+	if got := slices.Collect(s.Range(0, 10)); len(got) != 0 {
+		t.Errorf("Range() yields %#v", got)
+	}
+	s.Remove(1)
+	s.Append(1)
+	s.Clear()
+	if s.Len() != 0 {
+		t.Error("Clear() leaves elements")
+	}
+}
+
+func TestSortedSet_zero(t *testing.T) {
+	var s coll.SortedSet[int]
+	if s.Len() != 0 || s.Contains(1) || s.Rank(1) != 0 {
+		t.Error("the zero value is not empty")
+	}
+	if _, ok := s.Floor(1); ok {
+		t.Error("Floor() of the zero value reports an element")
+	}
+	s.Remove(1)
+	s.Append(2)
+	s.Append(1)
+	s.Append(3)
+	if got, want := slices.Collect(s.Values()), []int{1, 2, 3}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values():\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+func TestSortedSetFunc_zero(t *testing.T) {
+	var s coll.SortedSetFunc[int]
+	if s.Len() != 0 || s.Contains(1) {
+		t.Error("the zero value is not empty")
+	}
+	s.Remove(1)
+	defer func() {
+		msg, ok := recover().(string)
+		if !ok || !strings.Contains(msg, "comparison function is not set") {
+			t.Errorf("Append() to the zero value panics with %#v", msg)
+		}
+	}()
+	s.Append(1)
+}
+
+func TestSortedSetFunc(t *testing.T) {
+	s := coll.NewSortedSetFunc(func(a, b string) int { return cmp.Compare(strings.ToLower(a), strings.ToLower(b)) }, "b", "C", "a", "B")
+	if got, want := slices.Collect(s.Values()), []string{"a", "b", "C"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values():\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if !s.Contains("c") {
+		t.Error("Contains() does not use the comparison function")
+	}
+	if got := s.Rank("c"); got != 2 {
+		t.Errorf("Rank(c) = %d, want 2", got)
+	}
+	if got, want := slices.Collect(coll.Union[string](s, coll.NewSet("d")).Values()), []string{"a", "b", "C", "d"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Union() does not keep the order of the sorted set:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+func TestSortedSet_model(t *testing.T) {
+	rng := rand.New(rand.NewPCG(5, 6))
+	s := coll.NewSortedSet[int]()
+	var model []int
+	for range 20000 {
+		x := rng.IntN(2000)
+		i, found := slices.BinarySearch(model, x)
+		if rng.IntN(3) == 0 {
+			s.Remove(x)
+			if found {
+				model = slices.Delete(model, i, i+1)
+			}
+		} else {
+			s.Append(x)
+			if !found {
+				model = slices.Insert(model, i, x)
+			}
+		}
+		if s.Len() != len(model) {
+			t.Fatalf("Len() = %d, want %d", s.Len(), len(model))
+		}
+		if got := s.Rank(x); got != i {
+			t.Fatalf("Rank(%d) = %d, want %d", x, got, i)
+		}
+		if len(model) == 0 {
+			continue
+		}
+		j := rng.IntN(len(model))
+		if got, _ := s.Select(j); got != model[j] {
+			t.Fatalf("Select(%d) = %d, want %d", j, got, model[j])
+		}
+		wantFloor, wantOK := 0, true
+		switch {
+		case i < len(model) && model[i] == x:
+			wantFloor = x
+		case i > 0:
+			wantFloor = model[i-1]
+		default:
+			wantOK = false
+		}
+		if floor, ok := s.Floor(x); floor != wantFloor || ok != wantOK {
+			t.Fatalf("Floor(%d) = (%d, %v), want (%d, %v)", x, floor, ok, wantFloor, wantOK)
+		}
+	}
+	if got := slices.Collect(s.Values()); !reflect.DeepEqual(model, got) {
+		t.Errorf("Values() mismatch:\n\twant: %#v\n\t got: %#v", model, got)
+	}
+	want := slices.Clone(model)
+	slices.Reverse(want)
+	if got := slices.Collect(s.Backward()); !reflect.DeepEqual(want, got) {
+		t.Errorf("Backward() mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	for range 100 {
+		lo := rng.IntN(2000)
+		hi := lo + rng.IntN(200)
+		from, _ := slices.BinarySearch(model, lo)
+		to, _ := slices.BinarySearch(model, hi)
+		if got := slices.Collect(s.Range(lo, hi)); !slices.Equal(model[from:to], got) {
+			t.Fatalf("Range(%d, %d) mismatch:\n\twant: %#v\n\t got: %#v", lo, hi, model[from:to], got)
+		}
+	}
+	for _, x := range slices.Clone(model) {
+		s.Remove(x)
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len() = %d after removing all elements", s.Len())
+	}
+}
+
+func TestSortedSet_predicates(t *testing.T) {
+	s := coll.NewSortedSet(1, 2, 3)
+	if !s.IsSubset(coll.NewSet(1, 2, 3, 4)) || !s.IsProperSubset(coll.NewSet(1, 2, 3, 4)) {
+		t.Error("expected a subset")
+	}
+	if !s.IsSuperset(coll.NewSet(2)) || !s.Equal(coll.NewOrderedSet(3, 2, 1)) || !s.IsDisjoint(coll.NewSet(4)) {
+		t.Error("predicates report unexpected results")
+	}
+	t.Run("with itself while a writer is active", func(t *testing.T) {
+		sets := map[string]interface {
+			coll.MutableSetLike[int]
+			IsSubset(other coll.SetLike[int]) bool
+			IsProperSubset(other coll.SetLike[int]) bool
+			IsDisjoint(other coll.SetLike[int]) bool
+			Equal(other coll.SetLike[int]) bool
+		}{
+			"SortedSet":     coll.NewSortedSet(1, 2, 3),
+			"SortedSetFunc": coll.NewSortedSetFunc(cmp.Compare[int], 1, 2, 3),
+		}
+		for name, s := range sets {
+			t.Run(name, func(t *testing.T) {
+				done := make(chan struct{})
+				go func() {
+					defer close(done)
+					for i := range 1000 {
+						s.Append(4 + i)
+					}
+				}()
+				for range 1000 {
+					if !s.Equal(s) || !s.IsSubset(s) || s.IsProperSubset(s) || s.IsDisjoint(s) {
+						t.Fatal("a set is not equal to itself")
+					}
+				}
+				<-done
+			})
+		}
+	})
+}
+
+func BenchmarkSortedSet_Append(b *testing.B) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	s := coll.NewSortedSet[int]()
+	b.ReportAllocs()
+	for b.Loop() {
+		s.Append(rng.IntN(1 << 20))
+	}
+}
+
+func BenchmarkSortedSet_Contains(b *testing.B) {
+	s := coll.NewSortedSet[int]()
+	for i := range 1 << 16 {
+		s.Append(i)
+	}
+	b.ReportAllocs()
+	i := 0
+	for b.Loop() {
+		_ = s.Contains(i & (1<<16 - 1))
+		i++
+	}
+}
@@ -0,0 +1,294 @@
+package coll
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedSQLElementType is returned when a set whose element type is neither a string nor an integer type
+	// is read from or written to a database.
+	ErrUnsupportedSQLElementType = errors.New("unsupported element type for SQL")
+	// ErrInvalidSQLArray is returned when a database value cannot be parsed as an array.
+	ErrInvalidSQLArray = errors.New("invalid SQL array")
+)
+
+// Scan implements [database/sql.Scanner] for sets of strings and integers.
+// It accepts PostgreSQL array literals such as {a,"b c"} and JSON arrays such as ["a","b c"],
+// and replaces the elements of the set with the scanned ones. NULL is scanned as an empty set.
+func (s *Set[E]) Scan(src any) error {
+	els, err := scanSQLArray[E](src)
+	if err != nil {
+		return err
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.unsync.replace(els)
+	return nil
+}
+
+// Value implements [driver.Valuer] for sets of strings and integers.
+// The set is encoded as a PostgreSQL array literal; use [AsJSONArray] for JSON columns.
+// A nil set is encoded as NULL.
+func (s *Set[E]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return sqlArrayValue[E](s)
+}
+
+// Scan implements [database/sql.Scanner] for sets of strings and integers.
+// It accepts PostgreSQL array literals such as {a,"b c"} and JSON arrays such as ["a","b c"],
+// and replaces the elements of the set with the scanned ones, keeping their order. NULL is scanned as an empty set.
+func (s *OrderedSet[E]) Scan(src any) error {
+	els, err := scanSQLArray[E](src)
+	if err != nil {
+		return err
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.unsync.replace(els)
+	return nil
+}
+
+// Value implements [driver.Valuer] for sets of strings and integers.
+// The set is encoded as a PostgreSQL array literal in insertion order; use [AsJSONArray] for JSON columns.
+// A nil set is encoded as NULL.
+func (s *OrderedSet[E]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return sqlArrayValue[E](s)
+}
+
+// Scan implements [database/sql.Scanner] in the same way as [Set.Scan].
+func (s *UnsyncSet[E]) Scan(src any) error {
+	els, err := scanSQLArray[E](src)
+	if err != nil {
+		return err
+	}
+	s.replace(els)
+	return nil
+}
+
+// Value implements [driver.Valuer] in the same format as [Set.Value].
+func (s *UnsyncSet[E]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return sqlArrayValue[E](s)
+}
+
+// Scan implements [database/sql.Scanner] in the same way as [OrderedSet.Scan].
+func (s *UnsyncOrderedSet[E]) Scan(src any) error {
+	els, err := scanSQLArray[E](src)
+	if err != nil {
+		return err
+	}
+	s.replace(els)
+	return nil
+}
+
+// Value implements [driver.Valuer] in the same format as [OrderedSet.Value].
+func (s *UnsyncOrderedSet[E]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return sqlArrayValue[E](s)
+}
+
+// AsJSONArray returns a [driver.Valuer] that encodes the set as a JSON array, for JSON columns.
+func AsJSONArray[E comparable](s SetLike[E]) driver.Valuer {
+	return jsonArrayValuer[E]{set: s}
+}
+
+type jsonArrayValuer[E comparable] struct {
+	set SetLike[E]
+}
+
+func (v jsonArrayValuer[E]) Value() (driver.Value, error) {
+	data, err := marshalJSONArray(v.set)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func sqlArrayValue[E comparable](s SetLike[E]) (driver.Value, error) {
+	kind, err := sqlElementKind[E]()
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	first := true
+	for el := range s.Values() {
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+		rv := reflect.ValueOf(el)
+		switch kind {
+		case reflect.String:
+			b.WriteByte('"')
+			for _, c := range []byte(rv.String()) {
+				if c == '"' || c == '\\' {
+					b.WriteByte('\\')
+				}
+				b.WriteByte(c)
+			}
+			b.WriteByte('"')
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			b.WriteString(strconv.FormatUint(rv.Uint(), 10))
+		default:
+			b.WriteString(strconv.FormatInt(rv.Int(), 10))
+		}
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+func scanSQLArray[E comparable](src any) ([]E, error) {
+	kind, err := sqlElementKind[E]()
+	if err != nil {
+		return nil, err
+	}
+	var text []byte
+	switch src := src.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		text = src
+	case string:
+		text = []byte(src)
+	default:
+		return nil, fmt.Errorf("%w: cannot scan %T", ErrInvalidSQLArray, src)
+	}
+	text = bytes.TrimSpace(text)
+	if len(text) > 0 && text[0] == '[' {
+		var els []E
+		if err := json.Unmarshal(text, &els); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSQLArray, err)
+		}
+		return els, nil
+	}
+	fields, err := parsePostgresArray(string(text))
+	if err != nil {
+		return nil, err
+	}
+	els := make([]E, 0, len(fields))
+	for _, field := range fields {
+		var el E
+		rv := reflect.ValueOf(&el).Elem()
+		switch kind {
+		case reflect.String:
+			rv.SetString(field)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n, err := strconv.ParseUint(field, 10, rv.Type().Bits())
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidSQLArray, err)
+			}
+			rv.SetUint(n)
+		default:
+			n, err := strconv.ParseInt(field, 10, rv.Type().Bits())
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidSQLArray, err)
+			}
+			rv.SetInt(n)
+		}
+		els = append(els, el)
+	}
+	return els, nil
+}
+
+func sqlElementKind[E comparable]() (reflect.Kind, error) {
+	typ := reflect.TypeFor[E]()
+	switch kind := typ.Kind(); kind {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return kind, nil
+	default:
+		return reflect.Invalid, fmt.Errorf("%w: %s", ErrUnsupportedSQLElementType, typ)
+	}
+}
+
+// parsePostgresArray splits a one-dimensional PostgreSQL array literal into its elements.
+func parsePostgresArray(text string) ([]string, error) {
+	if len(text) < 2 || text[0] != '{' || text[len(text)-1] != '}' {
+		return nil, fmt.Errorf("%w: %q is not an array literal", ErrInvalidSQLArray, text)
+	}
+	body := text[1 : len(text)-1]
+	if strings.TrimSpace(body) == "" {
+		return nil, nil
+	}
+	var fields []string
+	i := 0
+	for {
+		for i < len(body) && isPostgresArraySpace(body[i]) {
+			i++
+		}
+		if i == len(body) {
+			return nil, fmt.Errorf("%w: %q has an empty element", ErrInvalidSQLArray, text)
+		}
+		switch body[i] {
+		case '{':
+			return nil, fmt.Errorf("%w: multi-dimensional array %q is not supported", ErrInvalidSQLArray, text)
+		case '"':
+			var b strings.Builder
+			i++
+			for ; i < len(body) && body[i] != '"'; i++ {
+				if body[i] == '\\' {
+					i++
+					if i == len(body) {
+						break
+					}
+				}
+				b.WriteByte(body[i])
+			}
+			if i == len(body) {
+				return nil, fmt.Errorf("%w: %q has an unterminated quoted element", ErrInvalidSQLArray, text)
+			}
+			i++ // closing quote
+			fields = append(fields, b.String())
+		default:
+			start := i
+			for i < len(body) && body[i] != ',' {
+				if body[i] == '"' || body[i] == '{' || body[i] == '}' || body[i] == '\\' {
+					return nil, fmt.Errorf("%w: %q has an unexpected character", ErrInvalidSQLArray, text)
+				}
+				i++
+			}
+			field := strings.TrimRight(body[start:i], " \t\n\r\v\f")
+			if strings.EqualFold(field, "NULL") {
+				return nil, fmt.Errorf("%w: %q has a NULL element", ErrInvalidSQLArray, text)
+			}
+			fields = append(fields, field)
+		}
+		for i < len(body) && isPostgresArraySpace(body[i]) {
+			i++
+		}
+		if i == len(body) {
+			return fields, nil
+		}
+		if body[i] != ',' {
+			return nil, fmt.Errorf("%w: %q has an unexpected character", ErrInvalidSQLArray, text)
+		}
+		i++
+	}
+}
+
+func isPostgresArraySpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '\v', '\f':
+		return true
+	default:
+		return false
+	}
+}
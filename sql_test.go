@@ -0,0 +1,245 @@
+package coll_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+// stubDriver is a driver.Driver that records the arguments of Exec and returns the configured value from Query.
+type stubDriver struct {
+	queryResult driver.Value
+	execArgs    []driver.Value
+}
+
+var (
+	_ driver.Driver    = (*stubDriver)(nil)
+	_ driver.Connector = (*stubDriver)(nil)
+)
+
+func (d *stubDriver) Open(string) (driver.Conn, error)             { return &stubConn{driver: d}, nil }
+func (d *stubDriver) Connect(context.Context) (driver.Conn, error) { return d.Open("") }
+func (d *stubDriver) Driver() driver.Driver                        { return d }
+
+type stubConn struct {
+	driver *stubDriver
+}
+
+func (c *stubConn) Prepare(string) (driver.Stmt, error) { return &stubStmt{driver: c.driver}, nil }
+func (c *stubConn) Close() error                        { return nil }
+func (c *stubConn) Begin() (driver.Tx, error)           { return nil, errors.ErrUnsupported }
+
+type stubStmt struct {
+	driver *stubDriver
+}
+
+func (s *stubStmt) Close() error  { return nil }
+func (s *stubStmt) NumInput() int { return -1 }
+
+func (s *stubStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.driver.execArgs = args
+	return driver.RowsAffected(1), nil
+}
+
+func (s *stubStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &stubRows{value: s.driver.queryResult}, nil
+}
+
+type stubRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (r *stubRows) Columns() []string { return []string{"tags"} }
+func (r *stubRows) Close() error      { return nil }
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+func openStubDB(t *testing.T, queryResult driver.Value) (*sql.DB, *stubDriver) {
+	t.Helper()
+	drv := &stubDriver{queryResult: queryResult}
+	db := sql.OpenDB(drv)
+	t.Cleanup(func() { _ = db.Close() })
+	return db, drv
+}
+
+func TestOrderedSet_Value(t *testing.T) {
+	testCases := []struct {
+		arg  any
+		want driver.Value
+		name string
+	}{
+		{
+			name: "strings",
+			arg:  coll.NewOrderedSet("b", "a c", `q"u\o`, ""),
+			want: `{"b","a c","q\"u\\o",""}`,
+		},
+		{
+			name: "integers",
+			arg:  coll.NewOrderedSet[int64](3, -1, 2),
+			want: `{3,-1,2}`,
+		},
+		{
+			name: "unsigned integers",
+			arg:  coll.NewOrderedSet[uint8](255, 0),
+			want: `{255,0}`,
+		},
+		{
+			name: "empty",
+			arg:  coll.NewOrderedSet[string](),
+			want: `{}`,
+		},
+		{
+			name: "JSON array",
+			arg:  coll.AsJSONArray[string](coll.NewOrderedSet("b", "a")),
+			want: `["b","a"]`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, drv := openStubDB(t, nil)
+			if _, err := db.Exec("INSERT INTO t (tags) VALUES ($1)", tc.arg); err != nil {
+				t.Fatal(err)
+			}
+			if got := drv.execArgs; !reflect.DeepEqual([]driver.Value{tc.want}, got) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSet_Value(t *testing.T) {
+	db, drv := openStubDB(t, nil)
+	if _, err := db.Exec("INSERT INTO t (tags) VALUES ($1)", coll.NewSet(42)); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := []driver.Value{"{42}"}, drv.execArgs; !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+
+	_, err := db.Exec("INSERT INTO t (tags) VALUES ($1)", coll.NewSet(1.5))
+	if !errors.Is(err, coll.ErrUnsupportedSQLElementType) {
+		t.Errorf("expected ErrUnsupportedSQLElementType, got %v", err)
+	}
+}
+
+func TestValue_nil(t *testing.T) {
+	testCases := []struct {
+		arg  any
+		name string
+	}{
+		{name: "Set", arg: (*coll.Set[string])(nil)},
+		{name: "OrderedSet", arg: (*coll.OrderedSet[string])(nil)},
+		{name: "UnsyncSet", arg: (*coll.UnsyncSet[string])(nil)},
+		{name: "UnsyncOrderedSet", arg: (*coll.UnsyncOrderedSet[string])(nil)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, drv := openStubDB(t, nil)
+			if _, err := db.Exec("INSERT INTO t (tags) VALUES ($1)", tc.arg); err != nil {
+				t.Fatal(err)
+			}
+			if want, got := []driver.Value{nil}, drv.execArgs; !reflect.DeepEqual(want, got) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+			}
+		})
+	}
+}
+
+func TestOrderedSet_Scan(t *testing.T) {
+	testCases := []struct {
+		src  driver.Value
+		name string
+		want []string
+	}{
+		{
+			name: "array literal",
+			src:  []byte(`{b,a,"c d","q\"u\\o",b}`),
+			want: []string{"b", "a", "c d", `q"u\o`},
+		},
+		{
+			name: "array literal with spaces",
+			src:  ` { b , "a" } `,
+			want: []string{"b", "a"},
+		},
+		{
+			name: "empty array literal",
+			src:  `{}`,
+			want: nil,
+		},
+		{
+			name: "JSON array",
+			src:  []byte(`["b","a","b"]`),
+			want: []string{"b", "a"},
+		},
+		{
+			name: "NULL",
+			src:  nil,
+			want: nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, _ := openStubDB(t, tc.src)
+			got := coll.NewOrderedSet("discarded")
+			if err := db.QueryRow("SELECT tags FROM t").Scan(got); err != nil {
+				t.Fatal(err)
+			}
+			if gotSlice := slices.Collect(got.Values()); !reflect.DeepEqual(tc.want, gotSlice) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, gotSlice)
+			}
+		})
+	}
+}
+
+func TestSet_Scan(t *testing.T) {
+	db, _ := openStubDB(t, `{3,1,2}`)
+	got := new(coll.Set[uint16])
+	if err := db.QueryRow("SELECT ids FROM t").Scan(got); err != nil {
+		t.Fatal(err)
+	}
+	if gotSlice, want := slices.Sorted(got.Values()), []uint16{1, 2, 3}; !reflect.DeepEqual(want, gotSlice) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, gotSlice)
+	}
+}
+
+func TestOrderedSet_Scan_errors(t *testing.T) {
+	testCases := []struct {
+		src  driver.Value
+		name string
+	}{
+		{name: "not an array", src: `a,b`},
+		{name: "multi-dimensional", src: `{{1,2},{3,4}}`},
+		{name: "NULL element", src: `{1,NULL}`},
+		{name: "trailing comma", src: `{1,}`},
+		{name: "unterminated quote", src: `{"1}`},
+		{name: "out of range", src: `{128}`},
+		{name: "not a number", src: `{x}`},
+		{name: "invalid JSON", src: `[1,`},
+		{name: "unsupported source", src: int64(1)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, _ := openStubDB(t, tc.src)
+			got := coll.NewOrderedSet[int8]()
+			err := db.QueryRow("SELECT ids FROM t").Scan(got)
+			if !errors.Is(err, coll.ErrInvalidSQLArray) {
+				t.Errorf("expected ErrInvalidSQLArray, got %v", err)
+			}
+		})
+	}
+}
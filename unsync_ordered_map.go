@@ -0,0 +1,174 @@
+package coll
+
+import (
+	"iter"
+	"sync"
+)
+
+// NewUnsyncOrderedMap returns a new instance of [UnsyncOrderedMap].
+func NewUnsyncOrderedMap[K comparable, V any]() *UnsyncOrderedMap[K, V] {
+	m := &UnsyncOrderedMap[K, V]{
+		dirty:   map[K]*listElement[orderedMapEntry[K, V]]{},
+		entries: linkedList[orderedMapEntry[K, V]]{},
+	}
+	return m
+}
+
+// UnsyncOrderedMap represents a map that preserves insertion order of keys, without synchronization.
+// It offers the same API as [OrderedMap] but is NOT safe for concurrent use,
+// so it saves the locking cost when the map is confined to a single goroutine.
+type UnsyncOrderedMap[K comparable, V any] struct {
+	dirty   map[K]*listElement[orderedMapEntry[K, V]]
+	entries linkedList[orderedMapEntry[K, V]]
+}
+
+type orderedMapEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// Len returns the number of entries in the map.
+func (m *UnsyncOrderedMap[K, V]) Len() int { return m.entries.len }
+
+// Get retrieves the value associated with the given key.
+// The second return value indicates whether the key was found.
+func (m *UnsyncOrderedMap[K, V]) Get(key K) (V, bool) {
+	if e, ok := m.dirty[key]; ok {
+		return e.value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put inserts the key-value pair into the map if the key does not already exist.
+// The insertion order of keys is preserved.
+func (m *UnsyncOrderedMap[K, V]) Put(key K, value V) {
+	if _, found := m.dirty[key]; found {
+		return
+	}
+	m.Set(key, value)
+}
+
+// Set associates the value with the key.
+// If the key already exists, its value is overwritten and the key keeps its position;
+// otherwise the key is appended.
+func (m *UnsyncOrderedMap[K, V]) Set(key K, value V) {
+	if e, found := m.dirty[key]; found {
+		e.value.value = value
+		return
+	}
+	if m.dirty == nil {
+		m.dirty = map[K]*listElement[orderedMapEntry[K, V]]{}
+	}
+	m.dirty[key] = m.entries.pushBack(orderedMapEntry[K, V]{key: key, value: value})
+}
+
+// Update updates the value associated with the key using the provided function.
+// The updater function receives the current value (or zero value if not found) and a boolean indicating existence.
+// If the key already exists, it keeps its position; otherwise the key is appended.
+func (m *UnsyncOrderedMap[K, V]) Update(key K, update func(prev V, alreadyExist bool) V) {
+	m.Set(key, update(m.Get(key)))
+}
+
+func (m *UnsyncOrderedMap[K, V]) deleteElement(e *listElement[orderedMapEntry[K, V]]) {
+	delete(m.dirty, e.value.key)
+	m.entries.remove(e)
+}
+
+// Delete removes the key from the map and returns the value that was associated with it.
+// The second return value indicates whether the key was found.
+// The order of the remaining keys is preserved. It runs in O(1).
+func (m *UnsyncOrderedMap[K, V]) Delete(key K) (V, bool) {
+	e, found := m.dirty[key]
+	if !found {
+		var zero V
+		return zero, false
+	}
+	m.deleteElement(e)
+	return e.value.value, true
+}
+
+// DeleteFunc removes every entry for which del returns true.
+// The order of the remaining keys is preserved.
+func (m *UnsyncOrderedMap[K, V]) DeleteFunc(del func(key K, value V) bool) {
+	for e := range m.entries.all() {
+		if del(e.value.key, e.value.value) {
+			m.deleteElement(e)
+		}
+	}
+}
+
+// Clear removes all entries from the map.
+func (m *UnsyncOrderedMap[K, V]) Clear() {
+	clear(m.dirty)
+	m.entries.clear()
+}
+
+// replace replaces the entries of the map with the given keys and values, keeping their order.
+// A key without a corresponding value is associated with the zero value.
+func (m *UnsyncOrderedMap[K, V]) replace(keys []K, values []V) {
+	m.Clear()
+	for i, key := range keys {
+		var value V
+		if i < len(values) {
+			value = values[i]
+		}
+		m.Set(key, value)
+	}
+}
+
+// clone returns a copy of m, keeping the order of the entries.
+func (m *UnsyncOrderedMap[K, V]) clone() *UnsyncOrderedMap[K, V] {
+	ret := NewUnsyncOrderedMap[K, V]()
+	for key, value := range m.All() {
+		ret.Set(key, value)
+	}
+	return ret
+}
+
+// Sync returns a new [OrderedMap] containing a copy of the entries of m, keeping their order.
+// m is left unchanged; use [UnsyncOrderedMap.MoveToSync] to convert without copying.
+func (m *UnsyncOrderedMap[K, V]) Sync() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{unsync: *m.clone(), mux: sync.RWMutex{}}
+}
+
+// MoveToSync moves the entries of m into a new [OrderedMap] and leaves m empty.
+// The entries are not copied, so the conversion runs in O(1).
+func (m *UnsyncOrderedMap[K, V]) MoveToSync() *OrderedMap[K, V] {
+	ret := &OrderedMap[K, V]{unsync: *m, mux: sync.RWMutex{}}
+	*m = UnsyncOrderedMap[K, V]{dirty: nil, entries: linkedList[orderedMapEntry[K, V]]{}}
+	return ret
+}
+
+// Keys returns an iterator over the keys in insertion order.
+func (m *UnsyncOrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for e := range m.entries.all() {
+			if !yield(e.value.key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values in insertion order of their corresponding keys.
+func (m *UnsyncOrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for e := range m.entries.all() {
+			if !yield(e.value.value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over key-value pairs in insertion order.
+func (m *UnsyncOrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := range m.entries.all() {
+			if !yield(e.value.key, e.value.value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package coll_test
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func TestUnsyncOrderedMap(t *testing.T) {
+	m := coll.NewUnsyncOrderedMap[string, int]()
+	m.Put("b", 1)
+	m.Put("a", 2)
+	m.Put("b", 100) // Put does not overwrite
+	m.Set("c", 3)
+	m.Set("a", 20) // Set overwrites and keeps the position
+	m.Update("b", func(prev int, _ bool) int { return prev * 10 })
+	if got, found := m.Get("b"); !found || got != 10 {
+		t.Errorf("Get(b): got (%v, %v), want (10, true)", got, found)
+	}
+	if gotLen := m.Len(); gotLen != 3 {
+		t.Errorf("Len: got %d, want 3", gotLen)
+	}
+	if got, want := slices.Collect(m.Keys()), []string{"b", "a", "c"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Keys: got %v, want %v", got, want)
+	}
+	if got, want := slices.Collect(m.Values()), []int{10, 20, 3}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values: got %v, want %v", got, want)
+	}
+	if got, found := m.Delete("a"); !found || got != 20 {
+		t.Errorf("Delete(a): got (%v, %v), want (20, true)", got, found)
+	}
+	m.DeleteFunc(func(k string, _ int) bool { return k == "c" })
+	for k, v := range m.All() {
+		if k != "b" || v != 10 {
+			t.Errorf("All: got unexpected entry (%v, %v)", k, v)
+		}
+	}
+	m.Clear()
+	if gotLen := m.Len(); gotLen != 0 {
+		t.Errorf("Len: got %d, want 0", gotLen)
+	}
+}
+
+func TestUnsyncOrderedMap_empty(t *testing.T) {
+	m := new(coll.UnsyncOrderedMap[string, int])
+	if _, found := m.Get("a"); found {
+		t.Error("Get(a) reports found unexpectedly")
+	}
+	if _, found := m.Delete("a"); found {
+		t.Error("Delete(a) reports found unexpectedly")
+	}
+	m.Put("a", 1)
+	if got, _ := m.Get("a"); got != 1 {
+		t.Errorf("Get(a): got %v, want 1", got)
+	}
+}
+
+func TestUnsyncOrderedMap_Sync(t *testing.T) {
+	unsync := coll.NewUnsyncOrderedMap[string, int]()
+	unsync.Put("b", 1)
+	unsync.Put("a", 2)
+	synced := unsync.Sync()
+	unsync.Put("c", 3)
+	if got, want := slices.Collect(synced.Keys()), []string{"b", "a"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Sync(): got %v, want %v", got, want)
+	}
+	if got, want := slices.Collect(unsync.Keys()), []string{"b", "a", "c"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("the source map is modified: %v", got)
+	}
+
+	back := synced.Unsync()
+	synced.Put("d", 4)
+	if got, want := slices.Collect(back.Keys()), []string{"b", "a"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Unsync(): got %v, want %v", got, want)
+	}
+	if got, want := slices.Collect(synced.Keys()), []string{"b", "a", "d"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("the source map is modified: %v", got)
+	}
+}
+
+func TestUnsyncOrderedMap_MoveToSync(t *testing.T) {
+	unsync := coll.NewUnsyncOrderedMap[string, int]()
+	unsync.Put("b", 1)
+	unsync.Put("a", 2)
+	synced := unsync.MoveToSync()
+	if got, want := slices.Collect(synced.Keys()), []string{"b", "a"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("MoveToSync(): got %v, want %v", got, want)
+	}
+	if gotLen := unsync.Len(); gotLen != 0 {
+		t.Errorf("the source map still has %d entries", gotLen)
+	}
+	unsync.Put("c", 3)
+	if _, found := synced.Get("c"); found {
+		t.Error("the converted map shares the entries with the source map")
+	}
+
+	back := synced.MoveToUnsync()
+	if got, want := slices.Collect(back.Keys()), []string{"b", "a"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("MoveToUnsync(): got %v, want %v", got, want)
+	}
+	if gotLen := synced.Len(); gotLen != 0 {
+		t.Errorf("the source map still has %d entries", gotLen)
+	}
+	synced.Put("d", 4)
+	if _, found := back.Get("d"); found {
+		t.Error("the converted map shares the entries with the source map")
+	}
+}
+
+func BenchmarkOrderedMap_Get(b *testing.B) {
+	const size = 1_000
+	b.Run("OrderedMap", func(b *testing.B) {
+		m := coll.NewOrderedMap[int, int]()
+		for i := range size {
+			m.Put(i, i)
+		}
+		b.ResetTimer()
+		for i := range b.N {
+			_, _ = m.Get(i % size)
+		}
+	})
+	b.Run("UnsyncOrderedMap", func(b *testing.B) {
+		m := coll.NewUnsyncOrderedMap[int, int]()
+		for i := range size {
+			m.Put(i, i)
+		}
+		b.ResetTimer()
+		for i := range b.N {
+			_, _ = m.Get(i % size)
+		}
+	})
+}
+
+func BenchmarkOrderedMap_Set(b *testing.B) {
+	const size = 1_000
+	b.Run("OrderedMap", func(b *testing.B) {
+		m := coll.NewOrderedMap[int, int]()
+		for i := range b.N {
+			m.Set(i%size, i)
+		}
+	})
+	b.Run("UnsyncOrderedMap", func(b *testing.B) {
+		m := coll.NewUnsyncOrderedMap[int, int]()
+		for i := range b.N {
+			m.Set(i%size, i)
+		}
+	})
+}
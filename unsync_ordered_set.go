@@ -0,0 +1,238 @@
+package coll
+
+import (
+	"iter"
+	"sync"
+)
+
+// NewUnsyncOrderedSet returns a new [UnsyncOrderedSet] containing the provided elements.
+// Duplicates in the input are ignored, and insertion order is preserved.
+func NewUnsyncOrderedSet[E comparable](els ...E) *UnsyncOrderedSet[E] {
+	s := &UnsyncOrderedSet[E]{
+		existence: make(map[E]*listElement[E], len(els)),
+		values:    linkedList[E]{},
+	}
+	for _, v := range els {
+		s.Append(v)
+	}
+	return s
+}
+
+// UnsyncOrderedSet represents a set of comparable elements that maintains insertion order, without synchronization.
+// It offers the same API as [OrderedSet] but is NOT safe for concurrent use,
+// so it saves the locking cost when the set is confined to a single goroutine.
+type UnsyncOrderedSet[E comparable] struct {
+	existence map[E]*listElement[E]
+	values    linkedList[E]
+}
+
+// Len returns the number of elements in the set.
+func (s *UnsyncOrderedSet[E]) Len() int { return s.values.len }
+
+// Contains reports whether the element is present in the set.
+func (s *UnsyncOrderedSet[E]) Contains(el E) bool {
+	_, found := s.existence[el]
+	return found
+}
+
+// Append adds the element to the set if it does not already exist.
+// The insertion order is preserved.
+func (s *UnsyncOrderedSet[E]) Append(el E) {
+	if s.Contains(el) {
+		return
+	}
+	if s.existence == nil {
+		s.existence = map[E]*listElement[E]{}
+	}
+	s.existence[el] = s.values.pushBack(el)
+}
+
+// Values returns an iterator over the elements of the set in insertion order.
+func (s *UnsyncOrderedSet[E]) Values() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for e := range s.values.all() {
+			if !yield(e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Remove removes the element from the set if it exists.
+// The order of the remaining elements is preserved. It runs in O(1).
+func (s *UnsyncOrderedSet[E]) Remove(removedEl E) {
+	e, found := s.existence[removedEl]
+	if !found {
+		// short circuit
+		return
+	}
+	delete(s.existence, removedEl)
+	s.values.remove(e)
+}
+
+// Clear removes all elements from the set.
+func (s *UnsyncOrderedSet[E]) Clear() {
+	clear(s.existence)
+	s.values.clear()
+}
+
+// replace replaces the elements of the set with els, keeping their order.
+func (s *UnsyncOrderedSet[E]) replace(els []E) {
+	s.Clear()
+	for _, el := range els {
+		s.Append(el)
+	}
+}
+
+// clone returns a copy of s, keeping the order of the elements.
+func (s *UnsyncOrderedSet[E]) clone() *UnsyncOrderedSet[E] {
+	ret := NewUnsyncOrderedSet[E]()
+	for el := range s.Values() {
+		ret.Append(el)
+	}
+	return ret
+}
+
+// Sync returns a new [OrderedSet] containing a copy of the elements of s, keeping their order.
+// s is left unchanged; use [UnsyncOrderedSet.MoveToSync] to convert without copying.
+func (s *UnsyncOrderedSet[E]) Sync() *OrderedSet[E] {
+	return &OrderedSet[E]{unsync: *s.clone(), mux: sync.RWMutex{}}
+}
+
+// MoveToSync moves the elements of s into a new [OrderedSet] and leaves s empty.
+// The elements are not copied, so the conversion runs in O(1).
+func (s *UnsyncOrderedSet[E]) MoveToSync() *OrderedSet[E] {
+	ret := &OrderedSet[E]{unsync: *s, mux: sync.RWMutex{}}
+	*s = UnsyncOrderedSet[E]{existence: nil, values: linkedList[E]{}}
+	return ret
+}
+
+// SymmetricDifference returns a new [UnsyncOrderedSet] containing elements that are in s or other but not in both.
+// Elements of s come first in their order, followed by elements of other in their order.
+func (s *UnsyncOrderedSet[E]) SymmetricDifference(other *UnsyncOrderedSet[E]) *UnsyncOrderedSet[E] {
+	ret := NewUnsyncOrderedSet[E]()
+	buildSymmetricDifference(ret, s, other)
+	return ret
+}
+
+// Difference returns a new [UnsyncOrderedSet] containing elements that are in s but not in other.
+// The order of the elements in s is preserved.
+func (s *UnsyncOrderedSet[E]) Difference(other *UnsyncOrderedSet[E]) *UnsyncOrderedSet[E] {
+	ret := NewUnsyncOrderedSet[E]()
+	buildDifference(ret, s, other)
+	return ret
+}
+
+// Intersect returns a new [UnsyncOrderedSet] containing elements that are present in both s and other.
+// The order of the elements in s is preserved.
+func (s *UnsyncOrderedSet[E]) Intersect(other *UnsyncOrderedSet[E]) *UnsyncOrderedSet[E] {
+	ret := NewUnsyncOrderedSet[E]()
+	buildIntersection(ret, s, other)
+	return ret
+}
+
+// Union returns a new [UnsyncOrderedSet] containing all elements from both s and other.
+// Elements of s come first in their order, followed by the rest of other in their order.
+func (s *UnsyncOrderedSet[E]) Union(other *UnsyncOrderedSet[E]) *UnsyncOrderedSet[E] {
+	ret := NewUnsyncOrderedSet[E]()
+	buildUnion(ret, s, other)
+	return ret
+}
+
+// IsSubset reports whether every element of s is also in other.
+func (s *UnsyncOrderedSet[E]) IsSubset(other SetLike[E]) bool {
+	return IsSubset[E](s, other)
+}
+
+// IsProperSubset reports whether s is a subset of other and other has at least one element that s does not have.
+func (s *UnsyncOrderedSet[E]) IsProperSubset(other SetLike[E]) bool {
+	return IsProperSubset[E](s, other)
+}
+
+// IsSuperset reports whether every element of other is also in s.
+func (s *UnsyncOrderedSet[E]) IsSuperset(other SetLike[E]) bool {
+	return IsSuperset[E](s, other)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+func (s *UnsyncOrderedSet[E]) IsDisjoint(other SetLike[E]) bool {
+	return IsDisjoint[E](s, other)
+}
+
+// Equal reports whether s and other contain the same elements, regardless of their order.
+func (s *UnsyncOrderedSet[E]) Equal(other SetLike[E]) bool {
+	return Equal[E](s, other)
+}
+
+// EqualOrdered reports whether s and other contain the same elements in the same order.
+func (s *UnsyncOrderedSet[E]) EqualOrdered(other *UnsyncOrderedSet[E]) bool {
+	if s == other {
+		return true
+	}
+	return equalOrdered[E](s, other)
+}
+
+func (s *UnsyncOrderedSet[E]) isSelf(other SetLike[E]) bool {
+	o, ok := unwrapView(other).(*UnsyncOrderedSet[E])
+	return ok && o == s
+}
+
+// UnionWith adds all elements of other to s.
+// The elements of s keep their order, and the added elements follow in the iteration order of other.
+func (s *UnsyncOrderedSet[E]) UnionWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		return
+	}
+	for el := range other.Values() {
+		s.Append(el)
+	}
+}
+
+// IntersectWith removes the elements of s that are not present in other.
+// The remaining elements keep their order.
+func (s *UnsyncOrderedSet[E]) IntersectWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		return
+	}
+	for e := range s.values.all() {
+		if !other.Contains(e.value) {
+			s.Remove(e.value)
+		}
+	}
+}
+
+// DifferenceWith removes the elements of s that are present in other.
+// The remaining elements keep their order.
+func (s *UnsyncOrderedSet[E]) DifferenceWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		s.Clear()
+		return
+	}
+	if s.values.len <= other.Len() {
+		for e := range s.values.all() {
+			if other.Contains(e.value) {
+				s.Remove(e.value)
+			}
+		}
+		return
+	}
+	for el := range other.Values() {
+		s.Remove(el)
+	}
+}
+
+// SymmetricDifferenceWith updates s to contain the elements that are in s or other but not in both.
+// The remaining elements of s keep their order, and the added elements follow in the iteration order of other.
+func (s *UnsyncOrderedSet[E]) SymmetricDifferenceWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		s.Clear()
+		return
+	}
+	for el := range other.Values() {
+		if s.Contains(el) {
+			s.Remove(el)
+		} else {
+			s.Append(el)
+		}
+	}
+}
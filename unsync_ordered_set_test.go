@@ -0,0 +1,212 @@
+package coll_test
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func TestUnsyncOrderedSet(t *testing.T) {
+	strSet := coll.NewUnsyncOrderedSet("c", "a", "b")
+	if gotLen := strSet.Len(); gotLen != 3 {
+		t.Errorf("Len() returns unexpected value: %d", gotLen)
+	}
+	strSet.Append("z")
+	strSet.Append("a") // try to append existent element
+	strSet.Remove("c")
+	if got, want := slices.Collect(strSet.Values()), []string{"a", "b", "z"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values() returns the unexpected value:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if strSet.Contains("c") {
+		t.Error("the set says it DOES contain 'c'")
+	}
+	strSet.Clear()
+	if gotLen := strSet.Len(); gotLen != 0 {
+		t.Errorf("Len() returns unexpected value: %d", gotLen)
+	}
+	strSet.Append("d")
+	if got, want := slices.Collect(strSet.Values()), []string{"d"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values() returns the unexpected value:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+func TestUnsyncOrderedSet_empty(t *testing.T) {
+	nums := new(coll.UnsyncOrderedSet[int])
+	if got := nums.Contains(42); got {
+		t.Errorf("Contains(42) reports true unexpectedly")
+	}
+	nums.Remove(42)
+	nums.Append(42)
+	if got, want := slices.Collect(nums.Values()), []int{42}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values() returns the unexpected value:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+func TestUnsyncOrderedSet_setOperations(t *testing.T) {
+	lhs := coll.NewUnsyncOrderedSet("c", "b", "a")
+	rhs := coll.NewUnsyncOrderedSet("d", "c", "b")
+	testCases := []struct {
+		got  *coll.UnsyncOrderedSet[string]
+		name string
+		want []string
+	}{
+		{name: "SymmetricDifference", got: lhs.SymmetricDifference(rhs), want: []string{"a", "d"}},
+		{name: "Difference", got: lhs.Difference(rhs), want: []string{"a"}},
+		{name: "Intersect", got: lhs.Intersect(rhs), want: []string{"c", "b"}},
+		{name: "Union", got: lhs.Union(rhs), want: []string{"c", "b", "a", "d"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := slices.Collect(tc.got.Values()); !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestUnsyncOrderedSet_EqualOrdered(t *testing.T) {
+	xs := coll.NewUnsyncOrderedSet("a", "b", "c")
+	if !xs.EqualOrdered(coll.NewUnsyncOrderedSet("a", "b", "c")) {
+		t.Error("EqualOrdered() reports false unexpectedly")
+	}
+	if xs.EqualOrdered(coll.NewUnsyncOrderedSet("c", "b", "a")) {
+		t.Error("EqualOrdered() reports true unexpectedly")
+	}
+	if !xs.Equal(coll.NewSet("c", "b", "a")) {
+		t.Error("Equal() reports false unexpectedly")
+	}
+}
+
+func TestUnsyncOrderedSet_inPlaceOperations(t *testing.T) {
+	testCases := []struct {
+		apply func(s *coll.UnsyncOrderedSet[string], other coll.SetLike[string])
+		name  string
+		want  []string
+	}{
+		{
+			name:  "UnionWith",
+			apply: (*coll.UnsyncOrderedSet[string]).UnionWith,
+			want:  []string{"c", "a", "b", "d"},
+		},
+		{
+			name:  "IntersectWith",
+			apply: (*coll.UnsyncOrderedSet[string]).IntersectWith,
+			want:  []string{"c", "b"},
+		},
+		{
+			name:  "DifferenceWith",
+			apply: (*coll.UnsyncOrderedSet[string]).DifferenceWith,
+			want:  []string{"a"},
+		},
+		{
+			name:  "SymmetricDifferenceWith",
+			apply: (*coll.UnsyncOrderedSet[string]).SymmetricDifferenceWith,
+			want:  []string{"a", "d"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := coll.NewUnsyncOrderedSet("c", "a", "b")
+			tc.apply(s, coll.NewOrderedSet("b", "c", "d"))
+			if got := slices.Collect(s.Values()); !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestUnsyncOrderedSet_Sync(t *testing.T) {
+	unsync := coll.NewUnsyncOrderedSet("b", "a")
+	synced := unsync.Sync()
+	unsync.Append("c")
+	if got, want := slices.Collect(synced.Values()), []string{"b", "a"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Sync(): mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if got, want := slices.Collect(unsync.Values()), []string{"b", "a", "c"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("the source set is modified: %#v", got)
+	}
+
+	back := synced.Unsync()
+	synced.Append("d")
+	if got, want := slices.Collect(back.Values()), []string{"b", "a"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Unsync(): mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if got, want := slices.Collect(synced.Values()), []string{"b", "a", "d"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("the source set is modified: %#v", got)
+	}
+}
+
+func TestUnsyncOrderedSet_MoveToSync(t *testing.T) {
+	unsync := coll.NewUnsyncOrderedSet("b", "a")
+	synced := unsync.MoveToSync()
+	if got, want := slices.Collect(synced.Values()), []string{"b", "a"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("MoveToSync(): mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if gotLen := unsync.Len(); gotLen != 0 {
+		t.Errorf("the source set still has %d elements", gotLen)
+	}
+	unsync.Append("c")
+	if synced.Contains("c") {
+		t.Error("the converted set shares the elements with the source set")
+	}
+
+	back := synced.MoveToUnsync()
+	if got, want := slices.Collect(back.Values()), []string{"b", "a"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("MoveToUnsync(): mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if gotLen := synced.Len(); gotLen != 0 {
+		t.Errorf("the source set still has %d elements", gotLen)
+	}
+	synced.Append("d")
+	if got, want := slices.Collect(back.Values()), []string{"b", "a"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("the converted set shares the elements with the source set: %#v", got)
+	}
+}
+
+func BenchmarkOrderedSet_Contains(b *testing.B) {
+	const size = 1_000
+	b.Run("OrderedSet", func(b *testing.B) {
+		s := coll.NewOrderedSet[int]()
+		for i := range size {
+			s.Append(i)
+		}
+		b.ResetTimer()
+		for i := range b.N {
+			_ = s.Contains(i % size)
+		}
+	})
+	b.Run("UnsyncOrderedSet", func(b *testing.B) {
+		s := coll.NewUnsyncOrderedSet[int]()
+		for i := range size {
+			s.Append(i)
+		}
+		b.ResetTimer()
+		for i := range b.N {
+			_ = s.Contains(i % size)
+		}
+	})
+}
+
+func BenchmarkOrderedSet_Append(b *testing.B) {
+	for _, size := range []int{1_000, 100_000} {
+		b.Run("OrderedSet/"+strconv.Itoa(size), func(b *testing.B) {
+			for range b.N {
+				s := coll.NewOrderedSet[int]()
+				for i := range size {
+					s.Append(i)
+				}
+			}
+		})
+		b.Run("UnsyncOrderedSet/"+strconv.Itoa(size), func(b *testing.B) {
+			for range b.N {
+				s := coll.NewUnsyncOrderedSet[int]()
+				for i := range size {
+					s.Append(i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package coll
+
+import (
+	"iter"
+	"maps"
+	"sync"
+)
+
+// NewUnsyncSet returns a new [UnsyncSet] containing the provided elements.
+// Duplicates in the input are ignored.
+func NewUnsyncSet[E comparable](els ...E) *UnsyncSet[E] {
+	s := &UnsyncSet[E]{
+		values: make(map[E]struct{}, len(els)),
+	}
+	for _, v := range els {
+		s.Append(v)
+	}
+	return s
+}
+
+// UnsyncSet represents a set of comparable elements without synchronization.
+// It offers the same API as [Set] but is NOT safe for concurrent use,
+// so it saves the locking cost when the set is confined to a single goroutine.
+type UnsyncSet[E comparable] struct {
+	values map[E]struct{}
+}
+
+// Len returns the number of elements in the set.
+func (s *UnsyncSet[E]) Len() int { return len(s.values) }
+
+// Contains reports whether the element is present in the set.
+func (s *UnsyncSet[E]) Contains(el E) bool {
+	_, found := s.values[el]
+	return found
+}
+
+// Append adds the element to the set if it does not already exist.
+func (s *UnsyncSet[E]) Append(el E) {
+	if s.Contains(el) {
+		return
+	}
+	if s.values == nil {
+		s.values = map[E]struct{}{}
+	}
+	s.values[el] = struct{}{}
+}
+
+// Values returns an iterator over the elements of the set.
+func (s *UnsyncSet[E]) Values() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for el := range s.values {
+			if !yield(el) {
+				return
+			}
+		}
+	}
+}
+
+// Remove removes the element from the set if it exists.
+func (s *UnsyncSet[E]) Remove(removedEl E) {
+	delete(s.values, removedEl)
+}
+
+// Clear removes all elements from the set.
+func (s *UnsyncSet[E]) Clear() {
+	clear(s.values)
+}
+
+// replace replaces the elements of the set with els.
+func (s *UnsyncSet[E]) replace(els []E) {
+	s.Clear()
+	for _, el := range els {
+		s.Append(el)
+	}
+}
+
+// clone returns a copy of s.
+func (s *UnsyncSet[E]) clone() *UnsyncSet[E] {
+	return &UnsyncSet[E]{values: maps.Clone(s.values)}
+}
+
+// Sync returns a new [Set] containing a copy of the elements of s.
+// s is left unchanged; use [UnsyncSet.MoveToSync] to convert without copying.
+func (s *UnsyncSet[E]) Sync() *Set[E] {
+	return &Set[E]{unsync: *s.clone(), mux: sync.RWMutex{}}
+}
+
+// MoveToSync moves the elements of s into a new [Set] and leaves s empty.
+// The elements are not copied, so the conversion runs in O(1).
+func (s *UnsyncSet[E]) MoveToSync() *Set[E] {
+	ret := &Set[E]{unsync: *s, mux: sync.RWMutex{}}
+	*s = UnsyncSet[E]{values: nil}
+	return ret
+}
+
+// SymmetricDifference returns a new [UnsyncSet] containing elements that are in s or other but not in both.
+func (s *UnsyncSet[E]) SymmetricDifference(other *UnsyncSet[E]) *UnsyncSet[E] {
+	ret := NewUnsyncSet[E]()
+	buildSymmetricDifference(ret, s, other)
+	return ret
+}
+
+// Difference returns a new [UnsyncSet] containing elements that are in s but not in other.
+func (s *UnsyncSet[E]) Difference(other *UnsyncSet[E]) *UnsyncSet[E] {
+	ret := NewUnsyncSet[E]()
+	buildDifference(ret, s, other)
+	return ret
+}
+
+// Intersect returns a new [UnsyncSet] containing elements that are present in both s and other.
+func (s *UnsyncSet[E]) Intersect(other *UnsyncSet[E]) *UnsyncSet[E] {
+	ret := NewUnsyncSet[E]()
+	buildIntersection(ret, s, other)
+	return ret
+}
+
+// Union returns a new [UnsyncSet] containing all elements from both s and other.
+func (s *UnsyncSet[E]) Union(other *UnsyncSet[E]) *UnsyncSet[E] {
+	ret := NewUnsyncSet[E]()
+	buildUnion(ret, s, other)
+	return ret
+}
+
+// IsSubset reports whether every element of s is also in other.
+func (s *UnsyncSet[E]) IsSubset(other SetLike[E]) bool {
+	return IsSubset[E](s, other)
+}
+
+// IsProperSubset reports whether s is a subset of other and other has at least one element that s does not have.
+func (s *UnsyncSet[E]) IsProperSubset(other SetLike[E]) bool {
+	return IsProperSubset[E](s, other)
+}
+
+// IsSuperset reports whether every element of other is also in s.
+func (s *UnsyncSet[E]) IsSuperset(other SetLike[E]) bool {
+	return IsSuperset[E](s, other)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+func (s *UnsyncSet[E]) IsDisjoint(other SetLike[E]) bool {
+	return IsDisjoint[E](s, other)
+}
+
+// Equal reports whether s and other contain the same elements, regardless of their order.
+func (s *UnsyncSet[E]) Equal(other SetLike[E]) bool {
+	return Equal[E](s, other)
+}
+
+func (s *UnsyncSet[E]) isSelf(other SetLike[E]) bool {
+	o, ok := unwrapView(other).(*UnsyncSet[E])
+	return ok && o == s
+}
+
+// UnionWith adds all elements of other to s.
+func (s *UnsyncSet[E]) UnionWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		return
+	}
+	for el := range other.Values() {
+		s.Append(el)
+	}
+}
+
+// IntersectWith removes the elements of s that are not present in other.
+func (s *UnsyncSet[E]) IntersectWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		return
+	}
+	for el := range s.values {
+		if !other.Contains(el) {
+			delete(s.values, el)
+		}
+	}
+}
+
+// DifferenceWith removes the elements of s that are present in other.
+func (s *UnsyncSet[E]) DifferenceWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		s.Clear()
+		return
+	}
+	if len(s.values) <= other.Len() {
+		for el := range s.values {
+			if other.Contains(el) {
+				delete(s.values, el)
+			}
+		}
+		return
+	}
+	for el := range other.Values() {
+		s.Remove(el)
+	}
+}
+
+// SymmetricDifferenceWith updates s to contain the elements that are in s or other but not in both.
+func (s *UnsyncSet[E]) SymmetricDifferenceWith(other SetLike[E]) {
+	if s.isSelf(other) {
+		s.Clear()
+		return
+	}
+	for el := range other.Values() {
+		if s.Contains(el) {
+			s.Remove(el)
+		} else {
+			s.Append(el)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package coll_test
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+func TestUnsyncSet(t *testing.T) {
+	strSet := coll.NewUnsyncSet("c", "a", "b")
+	if gotLen := strSet.Len(); gotLen != 3 {
+		t.Errorf("Len() returns unexpected value: %d", gotLen)
+	}
+	if got, want := slices.Sorted(strSet.Values()), []string{"a", "b", "c"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Values() returns the unexpected value:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if !strSet.Contains("a") {
+		t.Error("the set says it DOES NOT contain 'a'")
+	}
+	strSet.Append("z")
+	strSet.Append("a") // try to append existent element
+	if gotLen := strSet.Len(); gotLen != 4 {
+		t.Errorf("Len() returns unexpected value: %d", gotLen)
+	}
+	strSet.Remove("a")
+	if strSet.Contains("a") {
+		t.Error("the set says it DOES contain 'a'")
+	}
+	strSet.Clear()
+	if gotLen := strSet.Len(); gotLen != 0 {
+		t.Errorf("Len() returns unexpected value: %d", gotLen)
+	}
+}
+
+func TestUnsyncSet_empty(t *testing.T) {
+	nums := new(coll.UnsyncSet[int])
+	if got := nums.Contains(42); got {
+		t.Errorf("Contains(42) reports true unexpectedly")
+	}
+	nums.Append(42)
+	if got := nums.Contains(42); !got {
+		t.Errorf("Contains(42) reports false unexpectedly")
+	}
+}
+
+func TestUnsyncSet_setOperations(t *testing.T) {
+	lhs := coll.NewUnsyncSet("a", "b", "c")
+	rhs := coll.NewUnsyncSet("b", "c", "d")
+	testCases := []struct {
+		got  *coll.UnsyncSet[string]
+		name string
+		want []string
+	}{
+		{name: "SymmetricDifference", got: lhs.SymmetricDifference(rhs), want: []string{"a", "d"}},
+		{name: "Difference", got: lhs.Difference(rhs), want: []string{"a"}},
+		{name: "Intersect", got: lhs.Intersect(rhs), want: []string{"b", "c"}},
+		{name: "Union", got: lhs.Union(rhs), want: []string{"a", "b", "c", "d"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := slices.Sorted(tc.got.Values()); !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestUnsyncSet_predicates(t *testing.T) {
+	xs := coll.NewUnsyncSet("a", "b")
+	if !xs.IsSubset(coll.NewOrderedSet("b", "a", "c")) {
+		t.Error("IsSubset() reports false unexpectedly")
+	}
+	if !xs.IsProperSubset(coll.NewSet("a", "b", "c")) {
+		t.Error("IsProperSubset() reports false unexpectedly")
+	}
+	if !xs.IsSuperset(coll.NewUnsyncSet("a")) {
+		t.Error("IsSuperset() reports false unexpectedly")
+	}
+	if !xs.IsDisjoint(coll.NewSet("c")) {
+		t.Error("IsDisjoint() reports false unexpectedly")
+	}
+	if !xs.Equal(coll.NewOrderedSet("b", "a")) {
+		t.Error("Equal() reports false unexpectedly")
+	}
+}
+
+func TestUnsyncSet_inPlaceOperations(t *testing.T) {
+	testCases := []struct {
+		apply func(s *coll.UnsyncSet[string], other coll.SetLike[string])
+		name  string
+		want  []string
+		self  []string
+	}{
+		{
+			name:  "UnionWith",
+			apply: (*coll.UnsyncSet[string]).UnionWith,
+			want:  []string{"a", "b", "c", "d"},
+			self:  []string{"a", "b", "c"},
+		},
+		{
+			name:  "IntersectWith",
+			apply: (*coll.UnsyncSet[string]).IntersectWith,
+			want:  []string{"b", "c"},
+			self:  []string{"a", "b", "c"},
+		},
+		{
+			name:  "DifferenceWith",
+			apply: (*coll.UnsyncSet[string]).DifferenceWith,
+			want:  []string{"a"},
+			self:  nil,
+		},
+		{
+			name:  "SymmetricDifferenceWith",
+			apply: (*coll.UnsyncSet[string]).SymmetricDifferenceWith,
+			want:  []string{"a", "d"},
+			self:  nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := coll.NewUnsyncSet("c", "a", "b")
+			tc.apply(s, coll.NewOrderedSet("b", "c", "d"))
+			if got := slices.Sorted(s.Values()); !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.want, got)
+			}
+		})
+		t.Run(tc.name+"/self", func(t *testing.T) {
+			s := coll.NewUnsyncSet("c", "a", "b")
+			tc.apply(s, s)
+			if got := slices.Sorted(s.Values()); !reflect.DeepEqual(tc.self, got) {
+				t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", tc.self, got)
+			}
+		})
+	}
+}
+
+func TestUnsyncSet_Sync(t *testing.T) {
+	unsync := coll.NewUnsyncSet("a", "b")
+	synced := unsync.Sync()
+	unsync.Append("c")
+	if got, want := slices.Sorted(synced.Values()), []string{"a", "b"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Sync(): mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if got, want := slices.Sorted(unsync.Values()), []string{"a", "b", "c"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("the source set is modified: %#v", got)
+	}
+
+	back := synced.Unsync()
+	synced.Append("d")
+	if got, want := slices.Sorted(back.Values()), []string{"a", "b"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("Unsync(): mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if got, want := slices.Sorted(synced.Values()), []string{"a", "b", "d"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("the source set is modified: %#v", got)
+	}
+}
+
+func TestUnsyncSet_MoveToSync(t *testing.T) {
+	unsync := coll.NewUnsyncSet("a", "b")
+	synced := unsync.MoveToSync()
+	if got, want := slices.Sorted(synced.Values()), []string{"a", "b"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("MoveToSync(): mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if gotLen := unsync.Len(); gotLen != 0 {
+		t.Errorf("the source set still has %d elements", gotLen)
+	}
+	unsync.Append("c") // the source set must stay usable and detached from the converted one
+	if synced.Contains("c") {
+		t.Error("the converted set shares the elements with the source set")
+	}
+
+	back := synced.MoveToUnsync()
+	if got, want := slices.Sorted(back.Values()), []string{"a", "b"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("MoveToUnsync(): mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+	if gotLen := synced.Len(); gotLen != 0 {
+		t.Errorf("the source set still has %d elements", gotLen)
+	}
+	synced.Append("d")
+	if back.Contains("d") {
+		t.Error("the converted set shares the elements with the source set")
+	}
+}
+
+func TestSetOperations_unsync(t *testing.T) {
+	got := coll.Union[string](coll.NewUnsyncSet("a"), coll.NewSet("b"))
+	if _, ok := got.(*coll.UnsyncSet[string]); !ok {
+		t.Errorf("Union() returns %T; want *coll.UnsyncSet[string]", got)
+	}
+	got = coll.Union[string](coll.NewUnsyncOrderedSet("b", "a"), coll.NewSet("c"))
+	if _, ok := got.(*coll.UnsyncOrderedSet[string]); !ok {
+		t.Errorf("Union() returns %T; want *coll.UnsyncOrderedSet[string]", got)
+	}
+	if want, got := []string{"b", "a", "c"}, slices.Collect(got.Values()); !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+func BenchmarkSet_Contains(b *testing.B) {
+	const size = 1_000
+	b.Run("Set", func(b *testing.B) {
+		s := coll.NewSet[int]()
+		for i := range size {
+			s.Append(i)
+		}
+		b.ResetTimer()
+		for i := range b.N {
+			_ = s.Contains(i % size)
+		}
+	})
+	b.Run("UnsyncSet", func(b *testing.B) {
+		s := coll.NewUnsyncSet[int]()
+		for i := range size {
+			s.Append(i)
+		}
+		b.ResetTimer()
+		for i := range b.N {
+			_ = s.Contains(i % size)
+		}
+	})
+}
+
+func BenchmarkSet_Append(b *testing.B) {
+	for _, size := range []int{1_000, 100_000} {
+		b.Run("Set/"+strconv.Itoa(size), func(b *testing.B) {
+			for range b.N {
+				s := coll.NewSet[int]()
+				for i := range size {
+					s.Append(i)
+				}
+			}
+		})
+		b.Run("UnsyncSet/"+strconv.Itoa(size), func(b *testing.B) {
+			for range b.N {
+				s := coll.NewUnsyncSet[int]()
+				for i := range size {
+					s.Append(i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package coll
+
+import "iter"
+
+// SetView is a read-only view of a set.
+// It exposes only the methods that read the set, and the underlying set cannot be recovered from it,
+// so it can be handed to code that must not modify the set.
+// The zero value is an empty view.
+type SetView[E comparable] struct {
+	set SetLike[E]
+}
+
+var _ SetLike[int] = SetView[int]{set: nil}
+
+// Len returns the number of elements in the set.
+func (v SetView[E]) Len() int {
+	if v.set == nil {
+		return 0
+	}
+	return v.set.Len()
+}
+
+// Contains reports whether the element is present in the set.
+func (v SetView[E]) Contains(el E) bool {
+	if v.set == nil {
+		return false
+	}
+	return v.set.Contains(el)
+}
+
+// Values returns an iterator over the elements of the set, in the order of the underlying set.
+func (v SetView[E]) Values() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		if v.set == nil {
+			return
+		}
+		v.set.Values()(yield)
+	}
+}
+
+// mapReader is the read-only part of the API of the ordered maps.
+type mapReader[K comparable, V any] interface {
+	Len() int
+	Get(key K) (V, bool)
+	Keys() iter.Seq[K]
+	Values() iter.Seq[V]
+	All() iter.Seq2[K, V]
+}
+
+// unwrapView returns the set that other is a view of, or other itself if it is not a view,
+// so that the in-place operations recognize a view of the receiver as the receiver itself.
+func unwrapView[E comparable](other SetLike[E]) SetLike[E] {
+	for {
+		view, ok := other.(SetView[E])
+		if !ok || view.set == nil {
+			return other
+		}
+		other = view.set
+	}
+}
+
+// MapView is a read-only view of an ordered map.
+// It exposes only the methods that read the map, and the underlying map cannot be recovered from it,
+// so it can be handed to code that must not modify the map.
+// The zero value is an empty view.
+type MapView[K comparable, V any] struct {
+	m mapReader[K, V]
+}
+
+// Len returns the number of entries in the map.
+func (v MapView[K, V]) Len() int {
+	if v.m == nil {
+		return 0
+	}
+	return v.m.Len()
+}
+
+// Get retrieves the value associated with the given key.
+// The second return value indicates whether the key was found.
+func (v MapView[K, V]) Get(key K) (V, bool) {
+	if v.m == nil {
+		var zero V
+		return zero, false
+	}
+	return v.m.Get(key)
+}
+
+// Keys returns an iterator over the keys in insertion order.
+func (v MapView[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		if v.m == nil {
+			return
+		}
+		v.m.Keys()(yield)
+	}
+}
+
+// Values returns an iterator over the values in insertion order of their corresponding keys.
+func (v MapView[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if v.m == nil {
+			return
+		}
+		v.m.Values()(yield)
+	}
+}
+
+// All returns an iterator over key-value pairs in insertion order.
+func (v MapView[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if v.m == nil {
+			return
+		}
+		v.m.All()(yield)
+	}
+}
+
+// ReadOnly returns a read-only view of s.
+// The view is live: it reflects later changes to s, and it is safe for concurrent use as s is.
+func (s *Set[E]) ReadOnly() SetView[E] {
+	return SetView[E]{set: s}
+}
+
+// Freeze returns a read-only view of a copy of the current elements of s.
+// The copy is detached from s and is never modified, so the view is immutable and safe for concurrent use.
+func (s *Set[E]) Freeze() SetView[E] {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return s.unsync.Freeze()
+}
+
+// ReadOnly returns a read-only view of s.
+// The view is live: it reflects later changes to s, and it is safe for concurrent use as s is.
+func (s *OrderedSet[E]) ReadOnly() SetView[E] {
+	return SetView[E]{set: s}
+}
+
+// Freeze returns a read-only view of a copy of the current elements of s, keeping their order.
+// The copy is detached from s and is never modified, so the view is immutable and safe for concurrent use.
+func (s *OrderedSet[E]) Freeze() SetView[E] {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return s.unsync.Freeze()
+}
+
+// ReadOnly returns a read-only view of m.
+// The view is live: it reflects later changes to m, and it is safe for concurrent use as m is.
+func (m *OrderedMap[K, V]) ReadOnly() MapView[K, V] {
+	return MapView[K, V]{m: m}
+}
+
+// Freeze returns a read-only view of a copy of the current entries of m, keeping their order.
+// The copy is detached from m and is never modified, so the view is immutable and safe for concurrent use.
+func (m *OrderedMap[K, V]) Freeze() MapView[K, V] {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return m.unsync.Freeze()
+}
+
+// ReadOnly returns a read-only view of s.
+// The view is live: it reflects later changes to s, and like s, it is not safe for concurrent use.
+func (s *UnsyncSet[E]) ReadOnly() SetView[E] {
+	return SetView[E]{set: s}
+}
+
+// Freeze returns a read-only view of a copy of the current elements of s.
+// The copy is detached from s and is never modified, so the view is immutable and safe for concurrent use.
+func (s *UnsyncSet[E]) Freeze() SetView[E] {
+	return SetView[E]{set: s.clone()}
+}
+
+// ReadOnly returns a read-only view of s.
+// The view is live: it reflects later changes to s, and like s, it is not safe for concurrent use.
+func (s *UnsyncOrderedSet[E]) ReadOnly() SetView[E] {
+	return SetView[E]{set: s}
+}
+
+// Freeze returns a read-only view of a copy of the current elements of s, keeping their order.
+// The copy is detached from s and is never modified, so the view is immutable and safe for concurrent use.
+func (s *UnsyncOrderedSet[E]) Freeze() SetView[E] {
+	return SetView[E]{set: s.clone()}
+}
+
+// ReadOnly returns a read-only view of m.
+// The view is live: it reflects later changes to m, and like m, it is not safe for concurrent use.
+func (m *UnsyncOrderedMap[K, V]) ReadOnly() MapView[K, V] {
+	return MapView[K, V]{m: m}
+}
+
+// Freeze returns a read-only view of a copy of the current entries of m, keeping their order.
+// The copy is detached from m and is never modified, so the view is immutable and safe for concurrent use.
+func (m *UnsyncOrderedMap[K, V]) Freeze() MapView[K, V] {
+	return MapView[K, V]{m: m.clone()}
+}
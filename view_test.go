@@ -0,0 +1,203 @@
+package coll_test
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/aereal/coll"
+)
+
+type viewableSet interface {
+	coll.MutableSetLike[string]
+	ReadOnly() coll.SetView[string]
+	Freeze() coll.SetView[string]
+}
+
+type viewableMap interface {
+	Put(key string, value int)
+	Delete(key string) (int, bool)
+	ReadOnly() coll.MapView[string, int]
+	Freeze() coll.MapView[string, int]
+}
+
+func TestSetView(t *testing.T) {
+	testCases := []struct {
+		newSet  func(els ...string) viewableSet
+		name    string
+		ordered bool
+	}{
+		{name: "Set", newSet: func(els ...string) viewableSet {
+			return coll.NewSet(els...)
+		}},
+		{name: "OrderedSet", ordered: true, newSet: func(els ...string) viewableSet {
+			return coll.NewOrderedSet(els...)
+		}},
+		{name: "UnsyncSet", newSet: func(els ...string) viewableSet {
+			return coll.NewUnsyncSet(els...)
+		}},
+		{name: "UnsyncOrderedSet", ordered: true, newSet: func(els ...string) viewableSet {
+			return coll.NewUnsyncOrderedSet(els...)
+		}},
+	}
+	collect := func(ordered bool, xs coll.SetLike[string]) []string {
+		if ordered {
+			return slices.Collect(xs.Values())
+		}
+		return slices.Sorted(xs.Values())
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tc.newSet("b", "a")
+			view := s.ReadOnly()
+			frozen := s.Freeze()
+			s.Append("c")
+			s.Remove("b")
+
+			if got := view.Len(); got != 2 {
+				t.Errorf("view: Len() = %d, want 2", got)
+			}
+			if !view.Contains("c") || view.Contains("b") {
+				t.Error("view: Contains() does not reflect the changes")
+			}
+			if got, want := collect(tc.ordered, view), []string{"a", "c"}; !reflect.DeepEqual(want, got) {
+				t.Errorf("view: Values() mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+			}
+
+			want := []string{"b", "a"}
+			if !tc.ordered {
+				want = []string{"a", "b"}
+			}
+			if got := collect(tc.ordered, frozen); !reflect.DeepEqual(want, got) {
+				t.Errorf("frozen: Values() mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+			}
+			if frozen.Contains("c") || !frozen.Contains("b") {
+				t.Error("frozen: Contains() reflects the changes")
+			}
+
+			var _ coll.SetLike[string] = view
+			if _, ok := any(view).(coll.MutableSetLike[string]); ok {
+				t.Error("the view can be asserted to a mutable set")
+			}
+		})
+	}
+}
+
+type selfUpdatableSet interface {
+	viewableSet
+	UnionWith(other coll.SetLike[string])
+	IntersectWith(other coll.SetLike[string])
+	DifferenceWith(other coll.SetLike[string])
+	SymmetricDifferenceWith(other coll.SetLike[string])
+	Equal(other coll.SetLike[string]) bool
+}
+
+func TestSetView_inPlaceOperationsWithOwnView(t *testing.T) {
+	newSets := map[string]func(els ...string) selfUpdatableSet{
+		"Set":              func(els ...string) selfUpdatableSet { return coll.NewSet(els...) },
+		"OrderedSet":       func(els ...string) selfUpdatableSet { return coll.NewOrderedSet(els...) },
+		"UnsyncSet":        func(els ...string) selfUpdatableSet { return coll.NewUnsyncSet(els...) },
+		"UnsyncOrderedSet": func(els ...string) selfUpdatableSet { return coll.NewUnsyncOrderedSet(els...) },
+	}
+	ops := []struct {
+		apply func(s selfUpdatableSet)
+		name  string
+		want  []string
+	}{
+		{name: "UnionWith", apply: func(s selfUpdatableSet) { s.UnionWith(s.ReadOnly()) }, want: []string{"a", "b"}},
+		{name: "IntersectWith", apply: func(s selfUpdatableSet) { s.IntersectWith(s.ReadOnly()) }, want: []string{"a", "b"}},
+		{name: "DifferenceWith", apply: func(s selfUpdatableSet) { s.DifferenceWith(s.ReadOnly()) }, want: nil},
+		{name: "SymmetricDifferenceWith", apply: func(s selfUpdatableSet) { s.SymmetricDifferenceWith(s.ReadOnly()) }, want: nil},
+	}
+	for name, newSet := range newSets {
+		for _, op := range ops {
+			t.Run(name+"/"+op.name, func(t *testing.T) {
+				s := newSet("b", "a")
+				op.apply(s)
+				if got := slices.Sorted(s.Values()); !reflect.DeepEqual(op.want, got) {
+					t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", op.want, got)
+				}
+			})
+		}
+		t.Run(name+"/Equal", func(t *testing.T) {
+			s := newSet("b", "a")
+			if !s.Equal(s.ReadOnly()) {
+				t.Error("the set is not equal to its own view")
+			}
+		})
+	}
+}
+
+func TestSetView_setOperations(t *testing.T) {
+	view := coll.NewOrderedSet("c", "b", "a").ReadOnly()
+	got := coll.Union[string](view, coll.NewSet("d"))
+	if want, got := []string{"c", "b", "a", "d"}, slices.Collect(got.Values()); !reflect.DeepEqual(want, got) {
+		t.Errorf("mismatch:\n\twant: %#v\n\t got: %#v", want, got)
+	}
+}
+
+func TestSetView_zero(t *testing.T) {
+	var view coll.SetView[int]
+	if view.Len() != 0 || view.Contains(1) || len(slices.Collect(view.Values())) != 0 {
+		t.Error("the zero value is not empty")
+	}
+}
+
+func TestMapView(t *testing.T) {
+	testCases := []struct {
+		newMap func() viewableMap
+		name   string
+	}{
+		{name: "OrderedMap", newMap: func() viewableMap {
+			return coll.NewOrderedMap[string, int]()
+		}},
+		{name: "UnsyncOrderedMap", newMap: func() viewableMap {
+			return coll.NewUnsyncOrderedMap[string, int]()
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := tc.newMap()
+			m.Put("b", 1)
+			m.Put("a", 2)
+			view := m.ReadOnly()
+			frozen := m.Freeze()
+			m.Put("c", 3)
+			m.Delete("b")
+
+			if got, want := slices.Collect(view.Keys()), []string{"a", "c"}; !reflect.DeepEqual(want, got) {
+				t.Errorf("view: Keys: got %v, want %v", got, want)
+			}
+			if got, want := slices.Collect(view.Values()), []int{2, 3}; !reflect.DeepEqual(want, got) {
+				t.Errorf("view: Values: got %v, want %v", got, want)
+			}
+			if got, found := view.Get("c"); !found || got != 3 {
+				t.Errorf("view: Get(c): got (%v, %v)", got, found)
+			}
+
+			if got := frozen.Len(); got != 2 {
+				t.Errorf("frozen: Len: got %d, want 2", got)
+			}
+			var gotKeys []string
+			for key := range frozen.All() {
+				gotKeys = append(gotKeys, key)
+			}
+			if want := []string{"b", "a"}; !reflect.DeepEqual(want, gotKeys) {
+				t.Errorf("frozen: All: got %v, want %v", gotKeys, want)
+			}
+			if _, found := frozen.Get("c"); found {
+				t.Error("frozen: Get(c) reflects the changes")
+			}
+		})
+	}
+}
+
+func TestMapView_zero(t *testing.T) {
+	var view coll.MapView[string, int]
+	if _, found := view.Get("a"); found || view.Len() != 0 {
+		t.Error("the zero value is not empty")
+	}
+	for range view.All() {
+		t.Error("the zero value yields entries")
+	}
+}